@@ -20,9 +20,9 @@ func getDeviceSizeWindows(file *os.File) (int64, error) {
 }
 
 func openDeviceLinux(devicePath string, write bool) (*os.File, error) {
-	flags := os.O_RDONLY | syscall.O_DIRECT
+	flags := os.O_RDONLY | syscall.O_DIRECT | syscall.O_NOATIME
 	if write {
-		flags = os.O_RDWR | syscall.O_DIRECT
+		flags = os.O_RDWR | syscall.O_DIRECT | syscall.O_NOATIME
 	}
 
 	file, err := os.OpenFile(devicePath, flags, 0666)
@@ -11,10 +11,14 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"4corners/device/smart"
+	"4corners/logging"
+	"4corners/sysstat"
 )
 
 type Config struct {
-	Device              string
+	Device              string // target URL: bare path or file:// (block device/file), dir:// (directory of files), s3:// (bucket/prefix), http(s):// (object endpoint)
 	ReadTPIOSize        string // e.g., "128k"
 	WriteTPIOSize       string // e.g., "64k"
 	ReadIOPSIOSize      string // e.g., "4k"
@@ -31,46 +35,245 @@ type Config struct {
 	WriteTPQueueDepth   int
 	ReadIOPSQueueDepth  int
 	WriteIOPSQueueDepth int
+
+	// Verify switches Engine.RunVerify on instead of a throughput/IOPS test:
+	// writes fill each block with a pattern derived purely from its offset
+	// and a seed, and reads recompute and compare it. See VerifyMode for the
+	// write/read ordering.
+	Verify           bool
+	VerifyMode       string // "write-then-read" (default) or "inline"
+	VerifyIOSize     string // e.g. "128k"
+	VerifyThreads    int
+	VerifyQueueDepth int
+	VerifyDuration   int // inline mode only: seconds to run before stopping
+
+	// SMART, when set, has the engine snapshot the target device's health
+	// attributes immediately before and after each test phase (see
+	// Results.SMART).
+	SMART bool
+
+	// IOMaxRBps, IOMaxWBps, IOMaxRIOPS, and IOMaxWIOPS cap the target
+	// device's I/O for the run via a transient Linux cgroup v2 scope (see
+	// Results.LatencyCurve). Zero means unrestricted ("max") for that
+	// dimension. Setting any of the four enables isolation.
+	IOMaxRBps  int64
+	IOMaxWBps  int64
+	IOMaxRIOPS int64
+	IOMaxWIOPS int64
+
+	// Devices, when it holds more than one entry, switches RunBenchmark into
+	// its multi-device path: every entry (not Device) is benchmarked
+	// concurrently with a barrier between phases, so "Read Throughput" (for
+	// example) runs on all devices in the same wall-clock window before any
+	// of them starts "Write Throughput". Leave empty (the default) to
+	// benchmark Device alone exactly as before.
+	Devices []string
+
+	// MaxConcurrentDevices caps how many of Devices run a phase at once; the
+	// rest queue and start as a slot frees up. Zero (the default) means
+	// unrestricted - every device in a phase starts together, same as before
+	// this field existed. Set to 1 to run devices one at a time instead of
+	// in parallel, e.g. to avoid saturating a shared bus or host controller.
+	MaxConcurrentDevices int
 }
 
 type Results struct {
-	ReadThroughputMBps      float64
-	ReadThroughputIOPS      float64
-	ReadTPLatencyMs         float64
-	ReadTPThreads           int
-	ReadTPDuration          int
-	ReadTPQueueDepth        int
-	WriteThroughputMBps     float64
-	WriteThroughputIOPS     float64
-	WriteTPLatencyMs        float64
-	WriteTPThreads          int
-	WriteTPDuration         int
-	WriteTPQueueDepth       int
-	ReadIOPSThroughputMBps  float64
-	ReadIOPS                float64
-	ReadIOPSLatencyMs       float64
-	ReadIOPSThreads         int
-	ReadIOPSDuration        int
-	ReadIOPSQueueDepth      int
-	WriteIOPSThroughputMBps float64
-	WriteIOPS               float64
-	WriteIOPSLatencyMs      float64
-	WriteIOPSThreads        int
-	WriteIOPSDuration       int
-	WriteIOPSQueueDepth     int
-	TestDate                time.Time
-	Config                  Config
+	ReadThroughputMBps        float64
+	ReadThroughputIOPS        float64
+	ReadTPLatencyMs           float64
+	ReadTPLatencyMinMs        float64
+	ReadTPLatencyP50Ms        float64
+	ReadTPLatencyP95Ms        float64
+	ReadTPLatencyP99Ms        float64
+	ReadTPLatencyP999Ms       float64
+	ReadTPLatencyP9999Ms      float64
+	ReadTPLatencyMaxMs        float64
+	ReadTPLatencyStdevMs      float64
+	ReadTPLatencyHistogram    []byte
+	ReadTPLatencyBuckets      []HistogramBucket
+	ReadTPLatencySparkline    string
+	ReadTPThreads             int
+	ReadTPDuration            int
+	ReadTPQueueDepth          int
+	WriteThroughputMBps       float64
+	WriteThroughputIOPS       float64
+	WriteTPLatencyMs          float64
+	WriteTPLatencyMinMs       float64
+	WriteTPLatencyP50Ms       float64
+	WriteTPLatencyP95Ms       float64
+	WriteTPLatencyP99Ms       float64
+	WriteTPLatencyP999Ms      float64
+	WriteTPLatencyP9999Ms     float64
+	WriteTPLatencyMaxMs       float64
+	WriteTPLatencyStdevMs     float64
+	WriteTPLatencyHistogram   []byte
+	WriteTPLatencyBuckets     []HistogramBucket
+	WriteTPLatencySparkline   string
+	WriteTPThreads            int
+	WriteTPDuration           int
+	WriteTPQueueDepth         int
+	ReadIOPSThroughputMBps    float64
+	ReadIOPS                  float64
+	ReadIOPSLatencyMs         float64
+	ReadIOPSLatencyMinMs      float64
+	ReadIOPSLatencyP50Ms      float64
+	ReadIOPSLatencyP95Ms      float64
+	ReadIOPSLatencyP99Ms      float64
+	ReadIOPSLatencyP999Ms     float64
+	ReadIOPSLatencyP9999Ms    float64
+	ReadIOPSLatencyMaxMs      float64
+	ReadIOPSLatencyStdevMs    float64
+	ReadIOPSLatencyHistogram  []byte
+	ReadIOPSLatencyBuckets    []HistogramBucket
+	ReadIOPSLatencySparkline  string
+	ReadIOPSThreads           int
+	ReadIOPSDuration          int
+	ReadIOPSQueueDepth        int
+	WriteIOPSThroughputMBps   float64
+	WriteIOPS                 float64
+	WriteIOPSLatencyMs        float64
+	WriteIOPSLatencyMinMs     float64
+	WriteIOPSLatencyP50Ms     float64
+	WriteIOPSLatencyP95Ms     float64
+	WriteIOPSLatencyP99Ms     float64
+	WriteIOPSLatencyP999Ms    float64
+	WriteIOPSLatencyP9999Ms   float64
+	WriteIOPSLatencyMaxMs     float64
+	WriteIOPSLatencyStdevMs   float64
+	WriteIOPSLatencyHistogram []byte
+	WriteIOPSLatencyBuckets   []HistogramBucket
+	WriteIOPSLatencySparkline string
+	WriteIOPSThreads          int
+	WriteIOPSDuration         int
+	WriteIOPSQueueDepth       int
+	TestDate                  time.Time
+	Config                    Config
+
+	// HostStats holds the 1Hz host/device samples taken while each test
+	// ran, keyed by test name ("Read Throughput", "Write Throughput",
+	// "Read IOPS", "Write IOPS").
+	HostStats map[string][]sysstat.Sample
+
+	// Verify fields are only populated by RunVerify. VerifySeed is the seed
+	// the pattern was generated from; RunVerify picks a fresh seed on every
+	// call and always writes the pattern before reading it back, so no later
+	// run takes a recorded seed as input.
+	VerifyMode          string
+	VerifySeed          int64
+	VerifyBlocksChecked int64
+	VerifyErrors        []VerifyError
+
+	// SMART holds the before/after health snapshots taken around each test
+	// phase, keyed by test name, when Config.SMART is set.
+	SMART map[string]SMARTStats
+
+	// LatencyCurve is the latency-vs-throughput sweep recorded when an
+	// IOMax IOPS cap is set: one point per step at a fraction of the
+	// configured cap, letting a QoS policy's actual knee be read off
+	// directly instead of inferred from a single capped run.
+	LatencyCurve []LatencyCurvePoint
+
+	// PerDevice holds one independent Results per device, keyed by target
+	// URL, when Config.Devices produced a multi-device run. The headline
+	// fields above (ReadThroughputMBps, ReadIOPS, ...) are the sum of every
+	// device's own value, for callers that just want an aggregate number;
+	// per-device latency, SMART, and host-stat detail only live here. Nil on
+	// a single-device run.
+	PerDevice map[string]*Results
+
+	// Events is the full timestamped event stream RunBenchmark emitted for
+	// this run (samples, phase boundaries, errors), captured whether or not
+	// a live OnEvent callback was registered. The json exporter writes it
+	// out alongside everything else, which is what turns a saved report
+	// into a replayable run for history.go and OverlayEvents.
+	Events []Event
+}
+
+// LatencyCurvePoint is one step of an IOMax isolation sweep: the IOPS cap
+// targeted for that step, what the engine actually achieved against it,
+// and the resulting latency distribution.
+type LatencyCurvePoint struct {
+	TargetIOPS   int64
+	AchievedIOPS float64
+	LatencyP50Ms float64
+	LatencyP99Ms float64
+}
+
+// SMARTStats is the before/after pair of device health snapshots taken
+// around one test phase.
+type SMARTStats struct {
+	Before smart.Info
+	After  smart.Info
 }
 
 type Engine struct {
-	stopChan chan bool
-	stopped  bool
+	stopChan      chan bool
+	stopped       bool
+	eventCallback EventCallback
+
+	// Logger receives one Infof/Errorf call for every phase boundary and
+	// error event emitted through emitEvent (see logEvent), in addition to
+	// whatever an EventCallback does with the raw Event. NewEngine sets it to
+	// a Logger with a stdout sink, so callers never need a nil-check; a frontend
+	// attaches its own sinks (console, file, WebSocket) via AddSink.
+	Logger *logging.Logger
+
+	// recordedEvents accumulates every event emitted during the current
+	// RunBenchmark call, independent of whether a caller registered an
+	// EventCallback via OnEvent, so the run's full event stream can be
+	// persisted to history (see Results.Events and history.go) even when
+	// nothing was watching live. eventsMu guards it since a multi-device run
+	// emits events from one goroutine per device.
+	eventsMu       sync.Mutex
+	recordedEvents []Event
 }
 
 func NewEngine() *Engine {
 	return &Engine{
 		stopChan: make(chan bool),
 		stopped:  false,
+		Logger:   logging.NewLogger(logging.StdoutSink{}),
+	}
+}
+
+// OnEvent registers cb to receive structured progress events (samples,
+// phase boundaries, errors) alongside the existing human-readable
+// progressCallback strings. Pass nil to stop receiving events.
+func (e *Engine) OnEvent(cb EventCallback) {
+	e.eventCallback = cb
+}
+
+// emitEvent stamps ev's Timestamp, records it for the current run's history
+// regardless of whether anything is watching live, and forwards it to the
+// registered EventCallback, if any.
+func (e *Engine) emitEvent(ev Event) {
+	ev.Timestamp = time.Now()
+
+	e.eventsMu.Lock()
+	e.recordedEvents = append(e.recordedEvents, ev)
+	e.eventsMu.Unlock()
+
+	e.logEvent(ev)
+
+	if e.eventCallback != nil {
+		e.eventCallback(ev)
+	}
+}
+
+// logEvent mirrors phase boundaries and errors to e.Logger, so a log
+// console or log file gets the same "phase started/finished/failed"
+// narrative as the graphs do, without every call site needing its own
+// Logger call. EventSample is deliberately skipped - at one event per
+// second per phase it would flood the console, and the live sparkline
+// panel (see TimeSeries) already covers per-second metrics.
+func (e *Engine) logEvent(ev Event) {
+	switch ev.Type {
+	case EventPhaseStart:
+		e.Logger.Infof(ev.Phase, "starting %s test", ev.TestKind)
+	case EventPhaseEnd:
+		e.Logger.Infof(ev.Phase, "%.2f MB/s | %.0f IOPS | %.2f ms avg", ev.MBps, ev.IOPS, ev.LatMs)
+	case EventError:
+		e.Logger.Errorf(ev.Phase, "%s", ev.Message)
 	}
 }
 
@@ -215,34 +418,66 @@ func (e *Engine) PrepDevice(devicePath string, progressCallback func(string)) er
 	return nil
 }
 
-func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Results, error) {
-	// Reset stop state for new run
-	e.Reset()
-
-	results := &Results{
-		TestDate: time.Now(),
-		Config:   config,
+// ApplyIsolation creates and enters a transient cgroup v2 scope capping
+// config.Device's I/O per config.IOMax* (see Config.IOMaxRBps and
+// friends), for callers that drive individual tests via RunSingleTest
+// instead of RunBenchmark and so need to apply isolation around the whole
+// run themselves. It returns a nil teardown if config asks for no caps;
+// callers must call a non-nil teardown once every test has finished.
+func (e *Engine) ApplyIsolation(config Config) (teardown func() error, err error) {
+	iso, err := applyIsolation(config)
+	if err != nil {
+		return nil, err
 	}
+	if iso == nil {
+		return nil, nil
+	}
+	return iso.teardown, nil
+}
 
-	// Run each test
-	tests := []struct {
-		name         string
-		ioSize       string
-		isWrite      bool
-		threads      int
-		duration     int
-		queueDepth   int
-		throughputFn func(float64)
-		iopsFn       func(float64)
-		latencyFn    func(float64)
-		threadsFn    func(int)
-		durationFn   func(int)
-		queueDepthFn func(int)
-	}{
+// benchTest describes one of the four fixed corner tests (Read/Write
+// Throughput, Read/Write IOPS) and where its results land: the *Fn closures
+// write into a specific Results instance captured by buildBenchTests, so the
+// same test table shape can target either the single shared Results
+// RunBenchmark builds or one Results per device in a multi-device run.
+type benchTest struct {
+	name         string
+	ioSize       string
+	isWrite      bool
+	threads      int
+	duration     int
+	queueDepth   int
+	throughputFn func(float64)
+	iopsFn       func(float64)
+	latencyFn    func(float64)
+	statsFn      func(LatencyStats)
+	threadsFn    func(int)
+	durationFn   func(int)
+	queueDepthFn func(int)
+}
+
+// buildBenchTests returns the four corner tests wired to write their output
+// into results, in the fixed order RunBenchmark (and the multi-device path)
+// runs them.
+func buildBenchTests(config Config, results *Results) []benchTest {
+	return []benchTest{
 		{"Read Throughput", config.ReadTPIOSize, false, config.ReadTPThreads, config.ReadTPDuration, config.ReadTPQueueDepth,
 			func(v float64) { results.ReadThroughputMBps = v },
 			func(v float64) { results.ReadThroughputIOPS = v },
 			func(v float64) { results.ReadTPLatencyMs = v },
+			func(s LatencyStats) {
+				results.ReadTPLatencyMinMs = s.MinMs
+				results.ReadTPLatencyP50Ms = s.P50Ms
+				results.ReadTPLatencyP95Ms = s.P95Ms
+				results.ReadTPLatencyP99Ms = s.P99Ms
+				results.ReadTPLatencyP999Ms = s.P999Ms
+				results.ReadTPLatencyP9999Ms = s.P9999Ms
+				results.ReadTPLatencyMaxMs = s.MaxMs
+				results.ReadTPLatencyStdevMs = s.StdevMs
+				results.ReadTPLatencyHistogram = s.Histogram
+				results.ReadTPLatencyBuckets = s.Buckets
+				results.ReadTPLatencySparkline = s.Sparkline
+			},
 			func(v int) { results.ReadTPThreads = v },
 			func(v int) { results.ReadTPDuration = v },
 			func(v int) { results.ReadTPQueueDepth = v }},
@@ -250,6 +485,19 @@ func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Re
 			func(v float64) { results.ReadIOPSThroughputMBps = v },
 			func(v float64) { results.ReadIOPS = v },
 			func(v float64) { results.ReadIOPSLatencyMs = v },
+			func(s LatencyStats) {
+				results.ReadIOPSLatencyMinMs = s.MinMs
+				results.ReadIOPSLatencyP50Ms = s.P50Ms
+				results.ReadIOPSLatencyP95Ms = s.P95Ms
+				results.ReadIOPSLatencyP99Ms = s.P99Ms
+				results.ReadIOPSLatencyP999Ms = s.P999Ms
+				results.ReadIOPSLatencyP9999Ms = s.P9999Ms
+				results.ReadIOPSLatencyMaxMs = s.MaxMs
+				results.ReadIOPSLatencyStdevMs = s.StdevMs
+				results.ReadIOPSLatencyHistogram = s.Histogram
+				results.ReadIOPSLatencyBuckets = s.Buckets
+				results.ReadIOPSLatencySparkline = s.Sparkline
+			},
 			func(v int) { results.ReadIOPSThreads = v },
 			func(v int) { results.ReadIOPSDuration = v },
 			func(v int) { results.ReadIOPSQueueDepth = v }},
@@ -257,6 +505,19 @@ func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Re
 			func(v float64) { results.WriteThroughputMBps = v },
 			func(v float64) { results.WriteThroughputIOPS = v },
 			func(v float64) { results.WriteTPLatencyMs = v },
+			func(s LatencyStats) {
+				results.WriteTPLatencyMinMs = s.MinMs
+				results.WriteTPLatencyP50Ms = s.P50Ms
+				results.WriteTPLatencyP95Ms = s.P95Ms
+				results.WriteTPLatencyP99Ms = s.P99Ms
+				results.WriteTPLatencyP999Ms = s.P999Ms
+				results.WriteTPLatencyP9999Ms = s.P9999Ms
+				results.WriteTPLatencyMaxMs = s.MaxMs
+				results.WriteTPLatencyStdevMs = s.StdevMs
+				results.WriteTPLatencyHistogram = s.Histogram
+				results.WriteTPLatencyBuckets = s.Buckets
+				results.WriteTPLatencySparkline = s.Sparkline
+			},
 			func(v int) { results.WriteTPThreads = v },
 			func(v int) { results.WriteTPDuration = v },
 			func(v int) { results.WriteTPQueueDepth = v }},
@@ -264,10 +525,53 @@ func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Re
 			func(v float64) { results.WriteIOPSThroughputMBps = v },
 			func(v float64) { results.WriteIOPS = v },
 			func(v float64) { results.WriteIOPSLatencyMs = v },
+			func(s LatencyStats) {
+				results.WriteIOPSLatencyMinMs = s.MinMs
+				results.WriteIOPSLatencyP50Ms = s.P50Ms
+				results.WriteIOPSLatencyP95Ms = s.P95Ms
+				results.WriteIOPSLatencyP99Ms = s.P99Ms
+				results.WriteIOPSLatencyP999Ms = s.P999Ms
+				results.WriteIOPSLatencyP9999Ms = s.P9999Ms
+				results.WriteIOPSLatencyMaxMs = s.MaxMs
+				results.WriteIOPSLatencyStdevMs = s.StdevMs
+				results.WriteIOPSLatencyHistogram = s.Histogram
+				results.WriteIOPSLatencyBuckets = s.Buckets
+				results.WriteIOPSLatencySparkline = s.Sparkline
+			},
 			func(v int) { results.WriteIOPSThreads = v },
 			func(v int) { results.WriteIOPSDuration = v },
 			func(v int) { results.WriteIOPSQueueDepth = v }},
 	}
+}
+
+func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Results, error) {
+	// Reset stop state for new run
+	e.Reset()
+	e.eventsMu.Lock()
+	e.recordedEvents = nil
+	e.eventsMu.Unlock()
+
+	if len(config.Devices) > 1 {
+		return e.runMultiDeviceBenchmark(config, progressCallback)
+	}
+
+	iso, err := applyIsolation(config)
+	if err != nil {
+		return nil, err
+	}
+	if iso != nil {
+		defer iso.teardown()
+	}
+
+	results := &Results{
+		TestDate:  time.Now(),
+		Config:    config,
+		HostStats: make(map[string][]sysstat.Sample),
+		SMART:     make(map[string]SMARTStats),
+	}
+
+	// Run each test
+	tests := buildBenchTests(config, results)
 
 	for _, test := range tests {
 		// Check if stopped
@@ -276,6 +580,7 @@ func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Re
 		}
 
 		progressCallback(fmt.Sprintf("\nRunning %s test...", test.name))
+		e.emitEvent(Event{Type: EventPhaseStart, Phase: test.name, TestKind: testKindOf(test.isWrite)})
 
 		ioSizeBytes, err := ParseSize(test.ioSize)
 		if err != nil {
@@ -287,30 +592,152 @@ func (e *Engine) RunBenchmark(config Config, progressCallback func(string)) (*Re
 		test.durationFn(test.duration)
 		test.queueDepthFn(test.queueDepth)
 
-		throughput, iops, latency, err := e.runSingleTest(config.Device, ioSizeBytes, test.threads, test.duration, test.queueDepth, test.isWrite, progressCallback)
+		var smartBefore smart.Info
+		if config.SMART {
+			smartBefore, _ = smart.Read(config.Device)
+		}
+
+		throughput, iops, latency, stats, hostStats, err := e.runSingleTest(config.Device, ioSizeBytes, test.threads, test.duration, test.queueDepth, test.isWrite, test.name, "", progressCallback)
 		if err != nil {
 			if e.stopped {
 				return nil, fmt.Errorf("benchmark stopped by user")
 			}
+			e.emitEvent(Event{Type: EventError, Phase: test.name, TestKind: testKindOf(test.isWrite), Message: err.Error()})
 			return nil, fmt.Errorf("%s test failed: %v", test.name, err)
 		}
 
 		test.throughputFn(throughput)
 		test.iopsFn(iops)
 		test.latencyFn(latency)
+		test.statsFn(stats)
+		results.HostStats[test.name] = hostStats
+
+		if config.SMART {
+			smartAfter, _ := smart.Read(config.Device)
+			results.SMART[test.name] = SMARTStats{Before: smartBefore, After: smartAfter}
+		}
 
 		progressCallback(fmt.Sprintf("%s: %.2f MB/s | %.0f IOPS | %.2f ms", test.name, throughput, iops, latency))
+		e.emitEvent(Event{
+			Type: EventPhaseEnd, Phase: test.name, TestKind: testKindOf(test.isWrite),
+			MBps: throughput, IOPS: iops, LatMs: latency,
+			LatP50Ms: stats.P50Ms, LatP95Ms: stats.P95Ms, LatP99Ms: stats.P99Ms, LatP999Ms: stats.P999Ms,
+		})
+	}
+
+	if iso != nil {
+		curve, err := e.runIOMaxSweep(iso, config, progressCallback)
+		if err != nil {
+			return nil, fmt.Errorf("io.max sweep failed: %v", err)
+		}
+		results.LatencyCurve = curve
 	}
 
+	e.eventsMu.Lock()
+	results.Events = e.recordedEvents
+	e.eventsMu.Unlock()
 	return results, nil
 }
 
-// RunSingleTest runs a single benchmark test and returns throughput, IOPS, and latency
-func (e *Engine) RunSingleTest(devicePath string, ioSize int64, threads int, duration int, queueDepth int, isWrite bool, progressCallback func(string)) (float64, float64, float64, error) {
-	return e.runSingleTest(devicePath, ioSize, threads, duration, queueDepth, isWrite, progressCallback)
+// sweepFractions is the set of IOPS-cap fractions runIOMaxSweep steps
+// through, chosen to bracket where a QoS policy's knee typically sits.
+var sweepFractions = []float64{0.10, 0.25, 0.50, 1.00}
+
+// sweepBlockSize, sweepThreads, and sweepDuration match the fixed-size
+// random-IO shape of the IOPS corner tests, scaled down in duration since
+// the sweep runs four short steps back to back rather than one long one.
+const (
+	sweepBlockSize = 4 * 1024
+	sweepThreads   = 16
+	sweepDuration  = 5
+)
+
+// runIOMaxSweep re-caps iso's IOPS limit to successive fractions of
+// config's configured cap and runs a short IOPS test at each step,
+// producing the latency-vs-throughput curve described on
+// Results.LatencyCurve. It sweeps reads if IOMaxRIOPS is set, otherwise
+// writes against IOMaxWIOPS.
+func (e *Engine) runIOMaxSweep(iso isolation, config Config, progressCallback func(string)) ([]LatencyCurvePoint, error) {
+	isWrite := config.IOMaxRIOPS == 0
+	iopsCap := config.IOMaxRIOPS
+	if isWrite {
+		iopsCap = config.IOMaxWIOPS
+	}
+	if iopsCap <= 0 {
+		return nil, nil
+	}
+
+	var curve []LatencyCurvePoint
+	for _, frac := range sweepFractions {
+		target := int64(float64(iopsCap) * frac)
+		if target <= 0 {
+			continue
+		}
+
+		var err error
+		if isWrite {
+			err = iso.setIOPSCap(0, target)
+		} else {
+			err = iso.setIOPSCap(target, 0)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		progressCallback(fmt.Sprintf("\nRunning io.max sweep step: target %d IOPS...", target))
+		_, achievedIOPS, _, stats, _, err := e.runSingleTest(config.Device, sweepBlockSize, sweepThreads, sweepDuration, 1, isWrite, "io.max Sweep", "", progressCallback)
+		if err != nil {
+			if e.stopped {
+				return nil, fmt.Errorf("benchmark stopped by user")
+			}
+			return nil, err
+		}
+
+		curve = append(curve, LatencyCurvePoint{
+			TargetIOPS:   target,
+			AchievedIOPS: achievedIOPS,
+			LatencyP50Ms: stats.P50Ms,
+			LatencyP99Ms: stats.P99Ms,
+		})
+		progressCallback(fmt.Sprintf("target %d IOPS: achieved %.0f IOPS | p50 %.2f ms | p99 %.2f ms", target, achievedIOPS, stats.P50Ms, stats.P99Ms))
+	}
+
+	return curve, nil
+}
+
+// RunSingleTest runs a single benchmark test and returns throughput, IOPS,
+// average latency, the full latency distribution, and the host/device
+// samples gathered during the run. phase is used only to label the
+// structured events an OnEvent callback receives (e.g. "Read Throughput");
+// it has no effect on the test itself.
+func (e *Engine) RunSingleTest(devicePath string, ioSize int64, threads int, duration int, queueDepth int, isWrite bool, phase string, progressCallback func(string)) (float64, float64, float64, LatencyStats, []sysstat.Sample, error) {
+	return e.runSingleTest(devicePath, ioSize, threads, duration, queueDepth, isWrite, phase, "", progressCallback)
+}
+
+// hostSummaryLine formats a Sample series into the one-line host-context
+// summary printed after each test, e.g. "Host: CPU 42% (p95 60%, iowait
+// 18%), avg qdepth 27, kernel IOPS 84123 vs measured 82011". ownMBps is the
+// benchmark's own achieved throughput, used to split the kernel-observed
+// disk traffic into what this run caused versus what other processes
+// caused; ownIOPS is the benchmark's own achieved IOPS, printed beside the
+// kernel's count.
+func hostSummaryLine(samples []sysstat.Sample, ownMBps, ownIOPS float64) string {
+	summary := sysstat.Summarize(samples, ownMBps)
+	if !summary.DiskAvailable {
+		return fmt.Sprintf("  Host: CPU %.0f%% (p95 %.0f%%, iowait %.0f%%)", summary.AvgCPUPercent, summary.P95CPUPercent, summary.AvgIOWaitPercent)
+	}
+	line := fmt.Sprintf("  Host: CPU %.0f%% (p95 %.0f%%, iowait %.0f%%), avg qdepth %.0f, kernel IOPS %.0f vs measured %.0f",
+		summary.AvgCPUPercent, summary.P95CPUPercent, summary.AvgIOWaitPercent, summary.AvgQueueDepth, summary.KernelIOPS, ownIOPS)
+	if summary.OtherProcessMBps > 0.01 {
+		line += fmt.Sprintf(", %.2f MB/s from other processes", summary.OtherProcessMBps)
+	}
+	return line
 }
 
-func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, duration int, queueDepth int, isWrite bool, progressCallback func(string)) (float64, float64, float64, error) {
+// deviceID tags every Event this call emits, so a multi-device run's GUI
+// consumer can route samples to the right per-device graph row; it's empty
+// for single-device runs (RunSingleTest always passes "").
+func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, duration int, queueDepth int, isWrite bool, phase string, deviceID string, progressCallback func(string)) (float64, float64, float64, LatencyStats, []sysstat.Sample, error) {
 	var totalOps atomic.Int64
 	var totalBytes atomic.Int64
 	var totalLatencyNs atomic.Int64
@@ -318,12 +745,19 @@ func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, dur
 	stopChan := make(chan bool)
 	workerErrors := make(chan error, threads)
 
+	// Each worker owns its own histogram while running, so hist.record never
+	// contends with another worker (its lock only guards against the live
+	// snapshot below); they're merged into one combined histogram below
+	// after all workers have stopped.
+	workerHists := make([]*latencyHistogram, threads)
+
 	// Start worker threads
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
+		workerHists[i] = &latencyHistogram{}
 		go func(threadID int) {
 			defer wg.Done()
-			err := e.worker(devicePath, ioSize, queueDepth, isWrite, &totalOps, &totalBytes, &totalLatencyNs, stopChan)
+			err := e.worker(devicePath, ioSize, queueDepth, isWrite, &totalOps, &totalBytes, &totalLatencyNs, stopChan, workerHists[threadID])
 			if err != nil {
 				select {
 				case workerErrors <- err:
@@ -339,11 +773,18 @@ func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, dur
 	case err := <-workerErrors:
 		close(stopChan)
 		wg.Wait()
-		return 0, 0, 0, fmt.Errorf("worker error: %v", err)
+		e.emitEvent(Event{Type: EventError, Phase: phase, TestKind: testKindOf(isWrite), DeviceID: deviceID, Message: err.Error()})
+		return 0, 0, 0, LatencyStats{}, nil, fmt.Errorf("worker error: %v", err)
 	default:
 		// No immediate errors, continue
 	}
 
+	// Sample host/device state at 1Hz for the duration of the test, so
+	// throughput/IOPS/latency can be read alongside the concurrent CPU load
+	// and the kernel's own view of the device queue.
+	sampler := sysstat.NewSampler(devicePath)
+	sampler.Start()
+
 	// Run for specified duration
 	startTime := time.Now()
 	ticker := time.NewTicker(time.Second)
@@ -378,7 +819,26 @@ func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, dur
 					latencyMs = float64(latencyDelta) / float64(opsDelta) / 1000000.0
 				}
 
-				progressCallback(fmt.Sprintf("\r  %.0fs: %.2f MB/s | %.0f IOPS | %.2f ms                    ", elapsed, mbps, iops, latencyMs))
+				progressCallback(fmt.Sprintf("\r  %.0fs: %.2f MB/s | %.0f IOPS | %.2f ms (band %s)                    ", elapsed, mbps, iops, latencyMs, latencyBand(latencyMs)))
+
+				// Snapshot the in-flight worker histograms so the event carries
+				// live tail-latency percentiles, not just the average. Workers
+				// keep recording into these counters concurrently, so merge
+				// reads each worker's histogram under its own lock (see
+				// latencyHistogram.merge) - a late-landing count can still miss
+				// this particular snapshot, but it settles to the exact
+				// EventPhaseEnd values once the test stops.
+				snapshot := &latencyHistogram{}
+				for _, h := range workerHists {
+					snapshot.merge(h)
+				}
+
+				e.emitEvent(Event{
+					Type: EventSample, Phase: phase, TestKind: testKindOf(isWrite), DeviceID: deviceID,
+					MBps: mbps, IOPS: iops, LatMs: latencyMs,
+					LatP50Ms: snapshot.percentile(50), LatP95Ms: snapshot.percentile(95),
+					LatP99Ms: snapshot.percentile(99), LatP999Ms: snapshot.percentile(99.9),
+				})
 			case <-stopChan:
 				return
 			case <-e.stopChan:
@@ -398,18 +858,24 @@ func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, dur
 
 	close(stopChan)
 	wg.Wait()
-
-	// Print newline to move to next line after progress updates
-	progressCallback("\n")
+	hostStats := sampler.Stop()
 
 	elapsed := time.Since(startTime).Seconds()
 	ops := totalOps.Load()
 	bytes := totalBytes.Load()
+	ownMBps := (float64(bytes) / elapsed) / (1024 * 1024)
+
+	// Print newline to move to next line after progress updates, then the
+	// host-context summary for this test.
+	progressCallback("\n")
+	progressCallback(hostSummaryLine(hostStats, ownMBps, float64(ops)/elapsed) + "\n")
 	latencyNs := totalLatencyNs.Load()
 
 	// Check if any operations completed
 	if ops == 0 {
-		return 0, 0, 0, fmt.Errorf("no I/O operations completed - this usually means the device could not be accessed. On Windows, physical drive access requires Administrator privileges")
+		err := fmt.Errorf("no I/O operations completed - this usually means the device could not be accessed. On Windows, physical drive access requires Administrator privileges")
+		e.emitEvent(Event{Type: EventError, Phase: phase, TestKind: testKindOf(isWrite), DeviceID: deviceID, Message: err.Error()})
+		return 0, 0, 0, LatencyStats{}, hostStats, err
 	}
 
 	// Calculate average latency in milliseconds
@@ -418,34 +884,59 @@ func (e *Engine) runSingleTest(devicePath string, ioSize int64, threads int, dur
 		avgLatencyMs = float64(latencyNs) / float64(ops) / 1000000.0
 	}
 
+	// Merge each worker's histogram into one now that every worker has
+	// stopped recording.
+	combined := &latencyHistogram{}
+	for _, h := range workerHists {
+		combined.merge(h)
+	}
+
 	// Return all three metrics: throughput, IOPS, latency
 	throughputMBps := (float64(bytes) / elapsed) / (1024 * 1024)
 	iops := float64(ops) / elapsed
 
-	return throughputMBps, iops, avgLatencyMs, nil
+	return throughputMBps, iops, avgLatencyMs, combined.stats(), hostStats, nil
 }
 
-func (e *Engine) worker(devicePath string, ioSize int64, queueDepth int, isWrite bool, totalOps *atomic.Int64, totalBytes *atomic.Int64, totalLatencyNs *atomic.Int64, stopChan chan bool) error {
-	var file *os.File
-	var err error
-
-	if isWrite {
-		file, err = openDeviceForWrite(devicePath)
-	} else {
-		file, err = openDeviceForRead(devicePath)
+func (e *Engine) worker(devicePath string, ioSize int64, queueDepth int, isWrite bool, totalOps *atomic.Int64, totalBytes *atomic.Int64, totalLatencyNs *atomic.Int64, stopChan chan bool, hist *latencyHistogram) error {
+	target, err := NewTarget(devicePath)
+	if err != nil {
+		return err
 	}
 
-	if err != nil {
+	if err := target.Open(isWrite); err != nil {
 		return err
 	}
-	defer file.Close()
+	defer target.Close()
 
-	// Get device size for random seeks
-	deviceSize, err := getDeviceSize(file)
+	deviceSize, err := target.Size()
 	if err != nil {
-		return fmt.Errorf("failed to get device size: %v", err)
+		return fmt.Errorf("failed to get target size: %v", err)
 	}
 
+	// On Linux (io_uring) and Windows (overlapped I/O on a completion port),
+	// if the target is backed by a single real file descriptor, keep
+	// queueDepth ops in flight from this one goroutine instead of relying on
+	// one synchronous ReadAt/WriteAt at a time. Fall back to the portable
+	// sync loop below for targets that can't be driven that way (e.g.
+	// HTTPTarget), or whenever workerIOUring returns an error - a setup
+	// failure (e.g. kernel < 5.1) or a failed op.
+	if runtime.GOOS == "linux" || runtime.GOOS == "windows" {
+		if fdt, ok := target.(fdTarget); ok {
+			if err := e.workerIOUring(fdt.fd(), ioSize, queueDepth, isWrite, totalOps, totalBytes, totalLatencyNs, stopChan, deviceSize, hist); err == nil {
+				return nil
+			}
+		}
+	}
+
+	return e.workerSync(target, ioSize, queueDepth, isWrite, totalOps, totalBytes, totalLatencyNs, stopChan, deviceSize, hist)
+}
+
+// workerSync is the portable synchronous I/O loop used for targets without a
+// file descriptor, and as a fallback whenever workerIOUring returns an error.
+func (e *Engine) workerSync(target Target, ioSize int64, queueDepth int, isWrite bool, totalOps *atomic.Int64, totalBytes *atomic.Int64, totalLatencyNs *atomic.Int64, stopChan chan bool, deviceSize int64, hist *latencyHistogram) error {
+	var err error
+
 	// Pre-allocate multiple buffers for queue depth
 	if queueDepth <= 0 {
 		queueDepth = 4 // Default
@@ -481,10 +972,7 @@ func (e *Engine) worker(devicePath string, ioSize int64, queueDepth int, isWrite
 	localOps := int64(0)
 	localBytes := int64(0)
 	localLatencyNs := int64(0)
-	
-	// Sample latency every N operations to reduce overhead (1% sampling)
-	latencySampleRate := 100
-	
+
 	// Fast path - tight loop with minimal overhead
 	bufferIdx := 0
 	loopCount := 0
@@ -510,29 +998,23 @@ func (e *Engine) worker(devicePath string, ioSize int64, queueDepth int, isWrite
 			offset := offsets[offsetIdx]
 			offsetIdx = (offsetIdx + 1) % len(offsets)
 
-			// Sample latency periodically to avoid overhead on every IO
-			measureLatency := (localOps % int64(latencySampleRate)) == 0
-			var opStart time.Time
-			if measureLatency {
-				opStart = time.Now()
-			}
+			opStart := time.Now()
 
 			var n int
 			if isWrite {
-				n, err = file.WriteAt(buffers[bufferIdx], offset)
+				n, err = target.WriteAt(buffers[bufferIdx], offset)
 			} else {
-				n, err = file.ReadAt(buffers[bufferIdx], offset)
+				n, err = target.ReadAt(buffers[bufferIdx], offset)
 			}
-			
+
 			if err == nil && n > 0 {
 				localOps++
 				localBytes += int64(n)
-				
-				if measureLatency {
-					latency := time.Since(opStart).Nanoseconds()
-					localLatencyNs += latency
-				}
-				
+
+				latency := time.Since(opStart).Nanoseconds()
+				localLatencyNs += latency
+				hist.record(latency)
+
 				// Batch update to reduce atomic contention
 				if localOps >= batchSize {
 					totalOps.Add(localOps)
@@ -0,0 +1,68 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType distinguishes the kinds of progress events RunBenchmark and
+// RunSingleTest emit through an EventCallback. This replaces having callers
+// scrape the human-readable progressCallback strings for graph data, which
+// broke silently whenever that text's format shifted.
+type EventType string
+
+const (
+	EventSample     EventType = "sample"
+	EventPhaseStart EventType = "phase_start"
+	EventPhaseEnd   EventType = "phase_end"
+	EventError      EventType = "error"
+)
+
+// Event is one newline-delimited JSON record describing a benchmark's
+// progress. Fields that don't apply to a given Type are left zero/omitted.
+// LatP50Ms/LatP95Ms/LatP99Ms/LatP999Ms are populated on both EventSample and
+// EventPhaseEnd: each EventSample carries a snapshot merged from the
+// in-flight worker histograms at that tick, so a live grapher can draw tail
+// latency without waiting for the test to finish. That snapshot reads each
+// worker's bucket counts while they're still being incremented on the hot
+// path, so it's best-effort - a count may land a tick late - but it
+// converges to the same values EventPhaseEnd reports once the test stops.
+type Event struct {
+	Type      EventType `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Phase     string    `json:"phase"`
+	TestKind  string    `json:"testKind,omitempty"` // "read" or "write"
+	DeviceID  string    `json:"deviceId,omitempty"` // set to the target URL on multi-device runs; empty otherwise
+	MBps      float64   `json:"mbps,omitempty"`
+	IOPS      float64   `json:"iops,omitempty"`
+	LatMs     float64   `json:"latMs,omitempty"`
+	LatP50Ms  float64   `json:"latP50Ms,omitempty"`
+	LatP95Ms  float64   `json:"latP95Ms,omitempty"`
+	LatP99Ms  float64   `json:"latP99Ms,omitempty"`
+	LatP999Ms float64   `json:"latP999Ms,omitempty"`
+	Message   string    `json:"message,omitempty"` // detail text, set on EventError
+}
+
+// EventCallback receives one Event per call. RunBenchmark and RunSingleTest
+// never batch multiple events into a single invocation, so each call can be
+// forwarded atomically - e.g. one webview Bind call or one stdout line per
+// event - without a consumer having to buffer and split records itself.
+type EventCallback func(Event)
+
+// MarshalNDJSON encodes e as a single JSON object followed by a newline, the
+// format headless/CLI consumers read off stdout or a pipe.
+func (e Event) MarshalNDJSON() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// testKindOf returns "write" or "read", the TestKind convention Event uses.
+func testKindOf(isWrite bool) string {
+	if isWrite {
+		return "write"
+	}
+	return "read"
+}
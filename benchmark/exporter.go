@@ -0,0 +1,334 @@
+package benchmark
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"4corners/sysstat"
+)
+
+// ReportExporter writes one Results run to outDir in its own format.
+// Each exporter writes its own file; ExportReports runs them in order and
+// stops at the first one that fails.
+type ReportExporter interface {
+	Name() string
+	Export(r *Results, outDir string) error
+}
+
+// ParseReportTags parses a "-report-tags" flag value ("key=val,key2=val2")
+// into the map the Prometheus and InfluxDB exporters turn into
+// labels/tags - e.g. host, drive model, firmware - for fleet-wide
+// dashboards built from many hosts running the same binary.
+func ParseReportTags(raw string) map[string]string {
+	tags := map[string]string{}
+	if raw == "" {
+		return tags
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return tags
+}
+
+// Exporters resolves each name in formats (json, txt, csv, prom, influx)
+// to its built-in ReportExporter, silently skipping names it doesn't
+// recognize. tags is only used by the prom and influx exporters.
+func Exporters(formats []string, tags map[string]string) []ReportExporter {
+	var exporters []ReportExporter
+	for _, format := range formats {
+		switch strings.ToLower(strings.TrimSpace(format)) {
+		case "json":
+			exporters = append(exporters, jsonExporter{})
+		case "txt":
+			exporters = append(exporters, textExporter{})
+		case "csv":
+			exporters = append(exporters, csvExporter{})
+		case "prom":
+			exporters = append(exporters, prometheusExporter{tags: tags})
+		case "influx":
+			exporters = append(exporters, influxExporter{tags: tags})
+		}
+	}
+	return exporters
+}
+
+// SaveReport writes the json and txt reports, preserving the behavior
+// callers had before ExportReports let them pick formats.
+func (r *Results) SaveReport(outputDir string) error {
+	return r.ExportReports(outputDir, []string{"json", "txt"}, nil)
+}
+
+// ExportReports runs every exporter named in formats against r, stopping
+// at the first one that fails.
+func (r *Results) ExportReports(outputDir string, formats []string, tags map[string]string) error {
+	for _, exporter := range Exporters(formats, tags) {
+		if err := exporter.Export(r, outputDir); err != nil {
+			return fmt.Errorf("%s export failed: %v", exporter.Name(), err)
+		}
+	}
+	return nil
+}
+
+// timestampedPath builds the "4corners-report-<timestamp>.<ext>" path the
+// json/txt/csv/influx exporters all write to, one file per run.
+func timestampedPath(outDir, ext string) string {
+	return filepath.Join(outDir, fmt.Sprintf("4corners-report-%s.%s", time.Now().Format("20060102-150405"), ext))
+}
+
+// writeAtomic writes data to a temp file in path's directory and renames
+// it into place, so a poller reading path (e.g. node_exporter's textfile
+// collector) never observes a partially-written file.
+func writeAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+type jsonExporter struct{}
+
+func (jsonExporter) Name() string { return "json" }
+
+func (jsonExporter) Export(r *Results, outDir string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	return os.WriteFile(timestampedPath(outDir, "json"), data, 0644)
+}
+
+type textExporter struct{}
+
+func (textExporter) Name() string { return "txt" }
+
+func (textExporter) Export(r *Results, outDir string) error {
+	return os.WriteFile(timestampedPath(outDir, "txt"), []byte(r.GenerateTextReport()), 0644)
+}
+
+// reportRow is one test phase's metrics in the stable column order the
+// CSV, Prometheus, and InfluxDB exporters all build from, so a run can be
+// diffed against prior runs without columns shifting as fields are added.
+type reportRow struct {
+	Test           string
+	Threads        int
+	DurationS      int
+	QueueDepth     int
+	ThroughputMBps float64
+	IOPS           float64
+	LatencyMinMs   float64
+	LatencyAvgMs   float64
+	LatencyP50Ms   float64
+	LatencyP95Ms   float64
+	LatencyP99Ms   float64
+	LatencyP999Ms  float64
+	LatencyP9999Ms float64
+	LatencyMaxMs   float64
+	LatencyStdevMs float64
+	HostCPUAvgPct  float64
+	HostCPUP95Pct  float64
+	KernelIOPS     float64
+	KernelMBps     float64
+}
+
+// reportRowHostStatsKey maps a reportRow's stable test name to the key
+// Results.HostStats and Results.SMART use, which instead match the
+// display names printed in GenerateTextReport.
+var reportRowHostStatsKey = map[string]string{
+	"read_tp":    "Read Throughput",
+	"write_tp":   "Write Throughput",
+	"read_iops":  "Read IOPS",
+	"write_iops": "Write IOPS",
+}
+
+// reportRows flattens Results' four fixed test phases into reportRow,
+// filling in the host-context summary for each from HostStats.
+func (r *Results) reportRows() []reportRow {
+	rows := []reportRow{
+		{
+			Test: "read_tp", Threads: r.ReadTPThreads, DurationS: r.ReadTPDuration, QueueDepth: r.ReadTPQueueDepth,
+			ThroughputMBps: r.ReadThroughputMBps, IOPS: r.ReadThroughputIOPS, LatencyMinMs: r.ReadTPLatencyMinMs, LatencyAvgMs: r.ReadTPLatencyMs,
+			LatencyP50Ms: r.ReadTPLatencyP50Ms, LatencyP95Ms: r.ReadTPLatencyP95Ms, LatencyP99Ms: r.ReadTPLatencyP99Ms,
+			LatencyP999Ms: r.ReadTPLatencyP999Ms, LatencyP9999Ms: r.ReadTPLatencyP9999Ms, LatencyMaxMs: r.ReadTPLatencyMaxMs, LatencyStdevMs: r.ReadTPLatencyStdevMs,
+		},
+		{
+			Test: "write_tp", Threads: r.WriteTPThreads, DurationS: r.WriteTPDuration, QueueDepth: r.WriteTPQueueDepth,
+			ThroughputMBps: r.WriteThroughputMBps, IOPS: r.WriteThroughputIOPS, LatencyMinMs: r.WriteTPLatencyMinMs, LatencyAvgMs: r.WriteTPLatencyMs,
+			LatencyP50Ms: r.WriteTPLatencyP50Ms, LatencyP95Ms: r.WriteTPLatencyP95Ms, LatencyP99Ms: r.WriteTPLatencyP99Ms,
+			LatencyP999Ms: r.WriteTPLatencyP999Ms, LatencyP9999Ms: r.WriteTPLatencyP9999Ms, LatencyMaxMs: r.WriteTPLatencyMaxMs, LatencyStdevMs: r.WriteTPLatencyStdevMs,
+		},
+		{
+			Test: "read_iops", Threads: r.ReadIOPSThreads, DurationS: r.ReadIOPSDuration, QueueDepth: r.ReadIOPSQueueDepth,
+			ThroughputMBps: r.ReadIOPSThroughputMBps, IOPS: r.ReadIOPS, LatencyMinMs: r.ReadIOPSLatencyMinMs, LatencyAvgMs: r.ReadIOPSLatencyMs,
+			LatencyP50Ms: r.ReadIOPSLatencyP50Ms, LatencyP95Ms: r.ReadIOPSLatencyP95Ms, LatencyP99Ms: r.ReadIOPSLatencyP99Ms,
+			LatencyP999Ms: r.ReadIOPSLatencyP999Ms, LatencyP9999Ms: r.ReadIOPSLatencyP9999Ms, LatencyMaxMs: r.ReadIOPSLatencyMaxMs, LatencyStdevMs: r.ReadIOPSLatencyStdevMs,
+		},
+		{
+			Test: "write_iops", Threads: r.WriteIOPSThreads, DurationS: r.WriteIOPSDuration, QueueDepth: r.WriteIOPSQueueDepth,
+			ThroughputMBps: r.WriteIOPSThroughputMBps, IOPS: r.WriteIOPS, LatencyMinMs: r.WriteIOPSLatencyMinMs, LatencyAvgMs: r.WriteIOPSLatencyMs,
+			LatencyP50Ms: r.WriteIOPSLatencyP50Ms, LatencyP95Ms: r.WriteIOPSLatencyP95Ms, LatencyP99Ms: r.WriteIOPSLatencyP99Ms,
+			LatencyP999Ms: r.WriteIOPSLatencyP999Ms, LatencyP9999Ms: r.WriteIOPSLatencyP9999Ms, LatencyMaxMs: r.WriteIOPSLatencyMaxMs, LatencyStdevMs: r.WriteIOPSLatencyStdevMs,
+		},
+	}
+
+	for i := range rows {
+		samples := r.HostStats[reportRowHostStatsKey[rows[i].Test]]
+		summary := sysstat.Summarize(samples, rows[i].ThroughputMBps)
+		rows[i].HostCPUAvgPct = summary.AvgCPUPercent
+		rows[i].HostCPUP95Pct = summary.P95CPUPercent
+		rows[i].KernelIOPS = summary.KernelIOPS
+		rows[i].KernelMBps = summary.KernelMBps
+	}
+	return rows
+}
+
+// csvColumns is the stable column order csvExporter writes.
+var csvColumns = []string{
+	"test", "threads", "duration_s", "queue_depth",
+	"throughput_mbps", "iops", "latency_min_ms", "latency_avg_ms", "latency_p50_ms", "latency_p95_ms", "latency_p99_ms", "latency_p999_ms", "latency_p9999_ms", "latency_max_ms", "latency_stdev_ms",
+	"host_cpu_avg_pct", "host_cpu_p95_pct", "kernel_iops", "kernel_mbps",
+}
+
+type csvExporter struct{}
+
+func (csvExporter) Name() string { return "csv" }
+
+func (csvExporter) Export(r *Results, outDir string) error {
+	f, err := os.Create(timestampedPath(outDir, "csv"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, row := range r.reportRows() {
+		record := []string{
+			row.Test,
+			strconv.Itoa(row.Threads),
+			strconv.Itoa(row.DurationS),
+			strconv.Itoa(row.QueueDepth),
+			strconv.FormatFloat(row.ThroughputMBps, 'f', 2, 64),
+			strconv.FormatFloat(row.IOPS, 'f', 2, 64),
+			strconv.FormatFloat(row.LatencyMinMs, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyAvgMs, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyP50Ms, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyP95Ms, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyP99Ms, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyP999Ms, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyP9999Ms, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyMaxMs, 'f', 3, 64),
+			strconv.FormatFloat(row.LatencyStdevMs, 'f', 3, 64),
+			strconv.FormatFloat(row.HostCPUAvgPct, 'f', 2, 64),
+			strconv.FormatFloat(row.HostCPUP95Pct, 'f', 2, 64),
+			strconv.FormatFloat(row.KernelIOPS, 'f', 2, 64),
+			strconv.FormatFloat(row.KernelMBps, 'f', 2, 64),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// sortedKeys returns m's keys in sorted order, so label/tag output is
+// deterministic across runs instead of following Go's randomized map
+// iteration.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// prometheusExporter writes the node_exporter textfile collector format:
+// one gauge line per metric per test phase, labeled with device and any
+// user-supplied -report-tags.
+type prometheusExporter struct {
+	tags map[string]string
+}
+
+func (prometheusExporter) Name() string { return "prom" }
+
+func (e prometheusExporter) Export(r *Results, outDir string) error {
+	var buf strings.Builder
+	labels := e.labelSuffix(r)
+	for _, row := range r.reportRows() {
+		testLabels := fmt.Sprintf(`test="%s"%s`, row.Test, labels)
+		fmt.Fprintf(&buf, "4corners_throughput_mbps{%s} %f\n", testLabels, row.ThroughputMBps)
+		fmt.Fprintf(&buf, "4corners_iops{%s} %f\n", testLabels, row.IOPS)
+		fmt.Fprintf(&buf, "4corners_latency_p50_ms{%s} %f\n", testLabels, row.LatencyP50Ms)
+		fmt.Fprintf(&buf, "4corners_latency_p99_ms{%s} %f\n", testLabels, row.LatencyP99Ms)
+		fmt.Fprintf(&buf, "4corners_kernel_iops{%s} %f\n", testLabels, row.KernelIOPS)
+	}
+	return writeAtomic(filepath.Join(outDir, "4corners.prom"), []byte(buf.String()))
+}
+
+// labelSuffix renders device plus e.tags as a Prometheus label fragment
+// starting with a comma, so callers can splice it directly after the
+// "test" label.
+func (e prometheusExporter) labelSuffix(r *Results) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `,device="%s"`, prometheusEscape(r.Config.Device))
+	for _, k := range sortedKeys(e.tags) {
+		fmt.Fprintf(&b, `,%s="%s"`, k, prometheusEscape(e.tags[k]))
+	}
+	return b.String()
+}
+
+// prometheusEscape escapes the characters the exposition format's label
+// value grammar treats specially: backslash, double-quote, and newline.
+// Backslash must be escaped first so it doesn't double-escape the
+// backslashes this function itself introduces for the other two.
+func prometheusEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// influxExporter writes InfluxDB line protocol, one line per test phase
+// per run, tagged with device and any user-supplied -report-tags.
+type influxExporter struct {
+	tags map[string]string
+}
+
+func (influxExporter) Name() string { return "influx" }
+
+func (e influxExporter) Export(r *Results, outDir string) error {
+	var buf strings.Builder
+	ts := r.TestDate.UnixNano()
+	for _, row := range r.reportRows() {
+		fmt.Fprintf(&buf, "4corners,device=%s,test=%s%s throughput_mbps=%f,iops=%f,latency_p50_ms=%f,latency_p99_ms=%f,kernel_iops=%f %d\n",
+			influxEscape(r.Config.Device), row.Test, e.tagSuffix(),
+			row.ThroughputMBps, row.IOPS, row.LatencyP50Ms, row.LatencyP99Ms, row.KernelIOPS, ts)
+	}
+	return os.WriteFile(timestampedPath(outDir, "influx"), []byte(buf.String()), 0644)
+}
+
+func (e influxExporter) tagSuffix() string {
+	var b strings.Builder
+	for _, k := range sortedKeys(e.tags) {
+		fmt.Fprintf(&b, ",%s=%s", influxEscape(k), influxEscape(e.tags[k]))
+	}
+	return b.String()
+}
+
+// influxEscape escapes the characters line protocol treats specially in
+// tag keys and values: comma, space, and equals.
+func influxEscape(s string) string {
+	return strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`).Replace(s)
+}
@@ -0,0 +1,297 @@
+package benchmark
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	histSubBucketBits  = 3
+	histSubBucketCount = 1 << histSubBucketBits // 8 sub-buckets per power of two
+	histMinBucketK     = 0
+	histMaxBucketK     = 36 // 2^36 ns ~= 68.7s, comfortably covers a 60s test
+	histNumBuckets     = (histMaxBucketK - histMinBucketK + 1) * histSubBucketCount
+)
+
+// histBucketEdgesNs holds the upper bound, in nanoseconds, of each histogram
+// bucket: 2^k * (1 + f/8) for k = 0..36, f = 0..7, giving ~12.5% relative
+// precision from roughly 1ns up to 60+ seconds.
+var histBucketEdgesNs [histNumBuckets]int64
+
+func init() {
+	idx := 0
+	for k := histMinBucketK; k <= histMaxBucketK; k++ {
+		base := int64(1) << uint(k)
+		for f := 0; f < histSubBucketCount; f++ {
+			histBucketEdgesNs[idx] = base + (base*int64(f))/int64(histSubBucketCount)
+			idx++
+		}
+	}
+}
+
+// latencyHistogram is a logarithmically bucketed latency accumulator. Each
+// instance is owned by a single worker goroutine during a test, but the 1Hz
+// progress ticker in RunBenchmark merges a live snapshot of every worker's
+// histogram concurrently with record(), so mu guards the fields below from
+// that torn read - not from concurrent writers, since only the owning
+// worker ever calls record(). minNs/sumNs/sumSqNs ride alongside the
+// buckets so min and stdev don't need a second pass over counts.
+type latencyHistogram struct {
+	mu      sync.Mutex
+	counts  [histNumBuckets]uint64
+	minNs   int64
+	sumNs   float64
+	sumSqNs float64
+}
+
+func (h *latencyHistogram) record(latencyNs int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[bucketIndex(latencyNs)]++
+	if h.minNs == 0 || latencyNs < h.minNs {
+		h.minNs = latencyNs
+	}
+	ns := float64(latencyNs)
+	h.sumNs += ns
+	h.sumSqNs += ns * ns
+}
+
+func bucketIndex(latencyNs int64) int {
+	idx := sort.Search(histNumBuckets, func(i int) bool {
+		return histBucketEdgesNs[i] >= latencyNs
+	})
+	if idx >= histNumBuckets {
+		idx = histNumBuckets - 1
+	}
+	return idx
+}
+
+// merge folds other's counts into h. other may be a live worker histogram
+// still being written by record(), so its fields are read under its own
+// lock; h itself is assumed unshared (a fresh local accumulator), matching
+// every call site in engine.go.
+func (h *latencyHistogram) merge(other *latencyHistogram) {
+	other.mu.Lock()
+	defer other.mu.Unlock()
+
+	for i := range h.counts {
+		h.counts[i] += other.counts[i]
+	}
+	if other.minNs != 0 && (h.minNs == 0 || other.minNs < h.minNs) {
+		h.minNs = other.minNs
+	}
+	h.sumNs += other.sumNs
+	h.sumSqNs += other.sumSqNs
+}
+
+func (h *latencyHistogram) total() uint64 {
+	var sum uint64
+	for _, c := range h.counts {
+		sum += c
+	}
+	return sum
+}
+
+// percentile returns the latency in milliseconds at the given percentile
+// (0-100), taken as the upper edge of the bucket containing that rank.
+func (h *latencyHistogram) percentile(p float64) float64 {
+	total := h.total()
+	if total == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p / 100 * float64(total)))
+	if target == 0 {
+		target = 1
+	}
+
+	var cumulative uint64
+	for i, c := range h.counts {
+		cumulative += c
+		if cumulative >= target {
+			return float64(histBucketEdgesNs[i]) / 1000000.0
+		}
+	}
+	return float64(histBucketEdgesNs[histNumBuckets-1]) / 1000000.0
+}
+
+// max returns the highest recorded latency in milliseconds.
+func (h *latencyHistogram) max() float64 {
+	for i := histNumBuckets - 1; i >= 0; i-- {
+		if h.counts[i] > 0 {
+			return float64(histBucketEdgesNs[i]) / 1000000.0
+		}
+	}
+	return 0
+}
+
+// min returns the lowest recorded latency in milliseconds.
+func (h *latencyHistogram) min() float64 {
+	return float64(h.minNs) / 1000000.0
+}
+
+// stdev returns the population standard deviation of recorded latencies in
+// milliseconds, computed from the running sum/sum-of-squares rather than a
+// second pass over every sample.
+func (h *latencyHistogram) stdev() float64 {
+	total := h.total()
+	if total == 0 {
+		return 0
+	}
+	n := float64(total)
+	meanNs := h.sumNs / n
+	variance := h.sumSqNs/n - meanNs*meanNs
+	if variance < 0 {
+		variance = 0 // guard against float rounding on near-constant latencies
+	}
+	return math.Sqrt(variance) / 1000000.0
+}
+
+// HistogramBucket is one non-empty bucket of a latencyHistogram, exposed so
+// JSON reports carry a decodable distribution instead of the opaque
+// serialize() blob.
+type HistogramBucket struct {
+	UpperBoundMs float64
+	Count        uint64
+}
+
+// buckets returns every non-empty bucket, upper bound ascending.
+func (h *latencyHistogram) buckets() []HistogramBucket {
+	var buckets []HistogramBucket
+	for i, c := range h.counts {
+		if c > 0 {
+			buckets = append(buckets, HistogramBucket{UpperBoundMs: float64(histBucketEdgesNs[i]) / 1000000.0, Count: c})
+		}
+	}
+	return buckets
+}
+
+// sparklineLevels are the block characters used to render a latency
+// distribution as a single line of text, lowest to highest density.
+const sparklineLevels = "▁▂▃▄▅▆▇█"
+
+// sparklineWidth is the number of columns a sparkline is downsampled to,
+// regardless of how many histogram buckets the recorded latencies span.
+const sparklineWidth = 40
+
+// sparkline renders the occupied bucket range as a compact ASCII/Unicode bar
+// chart, so a reader can spot a long tail at a glance without parsing the
+// percentile numbers.
+func (h *latencyHistogram) sparkline() string {
+	total := h.total()
+	if total == 0 {
+		return ""
+	}
+
+	firstIdx, lastIdx := -1, -1
+	for i, c := range h.counts {
+		if c > 0 {
+			if firstIdx == -1 {
+				firstIdx = i
+			}
+			lastIdx = i
+		}
+	}
+	span := lastIdx - firstIdx + 1
+
+	cols := make([]uint64, sparklineWidth)
+	for i := firstIdx; i <= lastIdx; i++ {
+		col := (i - firstIdx) * sparklineWidth / span
+		if col >= sparklineWidth {
+			col = sparklineWidth - 1
+		}
+		cols[col] += h.counts[i]
+	}
+
+	var maxCol uint64
+	for _, c := range cols {
+		if c > maxCol {
+			maxCol = c
+		}
+	}
+
+	levels := []rune(sparklineLevels)
+	out := make([]rune, 0, sparklineWidth)
+	for _, c := range cols {
+		if c == 0 {
+			out = append(out, ' ')
+			continue
+		}
+		level := int(c * uint64(len(levels)-1) / maxCol)
+		out = append(out, levels[level])
+	}
+	return string(out)
+}
+
+// serialize packs the bucket counts into a []byte (little-endian uint64 per
+// bucket) suitable for embedding in Results as an opaque blob for later
+// offline analysis; the bucket layout is private to this package.
+func (h *latencyHistogram) serialize() []byte {
+	buf := make([]byte, histNumBuckets*8)
+	for i, c := range h.counts {
+		binary.LittleEndian.PutUint64(buf[i*8:], c)
+	}
+	return buf
+}
+
+// LatencyStats is a point-in-time summary of a latencyHistogram, exported so
+// callers outside this package can read percentiles without depending on the
+// unexported histogram type itself.
+type LatencyStats struct {
+	MinMs, P50Ms, P95Ms, P99Ms, P999Ms, P9999Ms, MaxMs, StdevMs float64
+	Histogram                                                   []byte
+	Buckets                                                     []HistogramBucket
+	Sparkline                                                   string
+}
+
+// stats snapshots h into a LatencyStats.
+func (h *latencyHistogram) stats() LatencyStats {
+	return LatencyStats{
+		MinMs:     h.min(),
+		P50Ms:     h.percentile(50),
+		P95Ms:     h.percentile(95),
+		P99Ms:     h.percentile(99),
+		P999Ms:    h.percentile(99.9),
+		P9999Ms:   h.percentile(99.99),
+		MaxMs:     h.max(),
+		StdevMs:   h.stdev(),
+		Histogram: h.serialize(),
+		Buckets:   h.buckets(),
+		Sparkline: h.sparkline(),
+	}
+}
+
+// latencyBand returns a human-readable range label ("98us-106us") for the
+// histogram bucket a given latency (in milliseconds) falls into, used for
+// the periodic progress line during a run.
+func latencyBand(latencyMs float64) string {
+	latencyNs := int64(latencyMs * 1000000.0)
+	if latencyNs <= 0 {
+		return "0"
+	}
+	idx := bucketIndex(latencyNs)
+	hi := histBucketEdgesNs[idx]
+	lo := int64(0)
+	if idx > 0 {
+		lo = histBucketEdgesNs[idx-1]
+	}
+	return fmt.Sprintf("%s-%s", formatNs(lo), formatNs(hi))
+}
+
+// formatNs renders a nanosecond value using the coarsest unit that keeps at
+// least one significant digit.
+func formatNs(ns int64) string {
+	switch {
+	case ns < 1000:
+		return fmt.Sprintf("%dns", ns)
+	case ns < 1000000:
+		return fmt.Sprintf("%dus", ns/1000)
+	case ns < 1000000000:
+		return fmt.Sprintf("%dms", ns/1000000)
+	default:
+		return fmt.Sprintf("%.1fs", float64(ns)/1000000000.0)
+	}
+}
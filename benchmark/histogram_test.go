@@ -0,0 +1,81 @@
+package benchmark
+
+import "testing"
+
+// withinRelativeTolerance reports whether got is within pct percent of want,
+// accounting for the histogram's own ~12.5% bucket precision (see
+// histBucketEdgesNs's doc comment).
+func withinRelativeTolerance(got, want, pct float64) bool {
+	tolerance := want * pct / 100
+	return got >= want-tolerance && got <= want+tolerance
+}
+
+func TestLatencyHistogramPercentilesUniform(t *testing.T) {
+	h := &latencyHistogram{}
+	for i := 1; i <= 100; i++ {
+		h.record(int64(i) * 1000000) // 1ms .. 100ms
+	}
+
+	if p50 := h.percentile(50); !withinRelativeTolerance(p50, 50, 15) {
+		t.Errorf("p50: expected ~50ms, got %.2fms", p50)
+	}
+	if p99 := h.percentile(99); !withinRelativeTolerance(p99, 99, 15) {
+		t.Errorf("p99: expected ~99ms, got %.2fms", p99)
+	}
+}
+
+func TestLatencyHistogramMinMax(t *testing.T) {
+	h := &latencyHistogram{}
+	for _, ns := range []int64{5000000, 1000000, 9000000, 3000000} {
+		h.record(ns)
+	}
+
+	if min := h.min(); !withinRelativeTolerance(min, 1, 1) {
+		t.Errorf("min: expected ~1ms, got %.3fms", min)
+	}
+	if max := h.max(); !withinRelativeTolerance(max, 9, 15) {
+		t.Errorf("max: expected ~9ms, got %.3fms", max)
+	}
+}
+
+func TestLatencyHistogramEmptyIsZero(t *testing.T) {
+	h := &latencyHistogram{}
+	if p := h.percentile(50); p != 0 {
+		t.Errorf("expected percentile of an empty histogram to be 0, got %v", p)
+	}
+	if m := h.max(); m != 0 {
+		t.Errorf("expected max of an empty histogram to be 0, got %v", m)
+	}
+	if s := h.stdev(); s != 0 {
+		t.Errorf("expected stdev of an empty histogram to be 0, got %v", s)
+	}
+}
+
+func TestLatencyHistogramMergeCombinesCounts(t *testing.T) {
+	a := &latencyHistogram{}
+	b := &latencyHistogram{}
+	for i := 0; i < 10; i++ {
+		a.record(1000000)
+	}
+	for i := 0; i < 5; i++ {
+		b.record(1000000)
+	}
+
+	merged := &latencyHistogram{}
+	merged.merge(a)
+	merged.merge(b)
+
+	if got, want := merged.total(), uint64(15); got != want {
+		t.Errorf("expected merged total of %d, got %d", want, got)
+	}
+}
+
+func TestLatencyHistogramStdevZeroForConstantLatency(t *testing.T) {
+	h := &latencyHistogram{}
+	for i := 0; i < 50; i++ {
+		h.record(2000000)
+	}
+	if s := h.stdev(); s != 0 {
+		t.Errorf("expected zero stdev for constant latency, got %v", s)
+	}
+}
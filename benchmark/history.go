@@ -0,0 +1,202 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunsDir returns the per-user directory SaveRun persists each run's
+// full Results (including its Events stream) to, creating it if it doesn't
+// exist yet.
+func RunsDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "4corners", "runs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// SaveRun writes r (including its Events stream) as a JSON file under
+// RunsDir, named by device and timestamp so ListRuns can sort runs without
+// parsing every file's contents first. It returns the path written.
+func (r *Results) SaveRun() (string, error) {
+	dir, err := RunsDir()
+	if err != nil {
+		return "", err
+	}
+
+	device := sanitizeFilename(filepath.Base(r.Config.Device))
+	if device == "" || device == "." {
+		device = "run"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", device, r.TestDate.Format("20060102-150405")))
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// sanitizeFilename replaces characters that aren't safe in a file name -
+// path separators, and the "://" a dir:// or s3:// target's Base can still
+// carry - with "_".
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// LoadRun reads a run JSON file - one SaveRun wrote, or any "json"-format
+// report ExportReports wrote - back into a Results.
+func LoadRun(path string) (*Results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Results
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", path, err)
+	}
+	return &r, nil
+}
+
+// RunMetadata summarizes one saved run for a Runs list without requiring
+// every full Results (and its event stream) to be loaded and rendered.
+type RunMetadata struct {
+	Path     string
+	Device   string
+	TestDate time.Time
+	PeakMBps float64
+	PeakIOPS float64
+	P99Ms    float64
+}
+
+// ListRuns returns metadata for every run under RunsDir, newest first.
+func ListRuns() ([]RunMetadata, error) {
+	dir, err := RunsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []RunMetadata
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		r, err := LoadRun(path)
+		if err != nil {
+			continue
+		}
+		runs = append(runs, RunMetadata{
+			Path:     path,
+			Device:   r.Config.Device,
+			TestDate: r.TestDate,
+			PeakMBps: maxOf(r.ReadThroughputMBps, r.WriteThroughputMBps),
+			PeakIOPS: maxOf(r.ReadIOPS, r.WriteIOPS),
+			P99Ms:    maxOf(r.ReadTPLatencyP99Ms, r.WriteTPLatencyP99Ms, r.ReadIOPSLatencyP99Ms, r.WriteIOPSLatencyP99Ms),
+		})
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].TestDate.After(runs[j].TestDate) })
+	return runs, nil
+}
+
+func maxOf(vals ...float64) float64 {
+	m := 0.0
+	for _, v := range vals {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// SampleEvent is the event shape OverlayEvents replays. It's an alias for
+// Event rather than a new type: a saved run's "sample" events already carry
+// everything the GUI's pushEvent/updateGraph path needs, so the distinct
+// name exists only for callers reading overlay code to see they're
+// consuming historical, not live, samples.
+type SampleEvent = Event
+
+// OverlayEvents replays r's recorded "sample" events over a channel, so a
+// GUI can overlay a prior run's graph without a second rendering code path.
+// Events are sent as fast as the channel is read - unlike a live run, a
+// replay has no reason to pace itself to real time. Phase/error events are
+// skipped since only samples drive updateGraph. The channel is closed once
+// every sample has been sent.
+func (r *Results) OverlayEvents() <-chan SampleEvent {
+	ch := make(chan SampleEvent)
+	go func() {
+		defer close(ch)
+		for _, ev := range r.Events {
+			if ev.Type != EventSample {
+				continue
+			}
+			ch <- ev
+		}
+	}()
+	return ch
+}
+
+// DiffReport renders the headline metric deltas between two runs (compare
+// minus baseline), for comparing a firmware update or filesystem change
+// against a known-good run. A zero baseline metric is reported without a
+// percentage, since dividing by zero isn't meaningful.
+func DiffReport(baseline, compare *Results) string {
+	var b strings.Builder
+	b.WriteString("========================================\n")
+	b.WriteString("4Corners Run Comparison\n")
+	b.WriteString("========================================\n\n")
+	fmt.Fprintf(&b, "Baseline: %s (%s)\n", baseline.Config.Device, baseline.TestDate.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "Compare:  %s (%s)\n\n", compare.Config.Device, compare.TestDate.Format("2006-01-02 15:04:05"))
+
+	rows := []struct {
+		name         string
+		base, compar float64
+	}{
+		{"Read Throughput (MB/s)", baseline.ReadThroughputMBps, compare.ReadThroughputMBps},
+		{"Write Throughput (MB/s)", baseline.WriteThroughputMBps, compare.WriteThroughputMBps},
+		{"Read IOPS", baseline.ReadIOPS, compare.ReadIOPS},
+		{"Write IOPS", baseline.WriteIOPS, compare.WriteIOPS},
+		{"Read TP Latency p99 (ms)", baseline.ReadTPLatencyP99Ms, compare.ReadTPLatencyP99Ms},
+		{"Write TP Latency p99 (ms)", baseline.WriteTPLatencyP99Ms, compare.WriteTPLatencyP99Ms},
+		{"Read IOPS Latency p99 (ms)", baseline.ReadIOPSLatencyP99Ms, compare.ReadIOPSLatencyP99Ms},
+		{"Write IOPS Latency p99 (ms)", baseline.WriteIOPSLatencyP99Ms, compare.WriteIOPSLatencyP99Ms},
+	}
+
+	for _, row := range rows {
+		delta := row.compar - row.base
+		pct := "n/a"
+		if row.base != 0 {
+			pct = fmt.Sprintf("%+.1f%%", (delta/row.base)*100)
+		}
+		fmt.Fprintf(&b, "  %-28s %12.2f -> %12.2f  (%+.2f, %s)\n", row.name, row.base, row.compar, delta, pct)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,336 @@
+//go:build linux
+// +build linux
+
+package benchmark
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	sysIoUringSetup = 425
+	sysIoUringEnter = 426
+
+	ioUringOpRead  = 22
+	ioUringOpWrite = 23
+
+	ioUringFeatSingleMmap = 1 << 0
+
+	ioUringOffSqRing = 0
+	ioUringOffCqRing = 0x8000000
+	ioUringOffSqes   = 0x10000000
+
+	ioUringEnterGetevents = 1 << 0
+)
+
+type ioSqringOffsets struct {
+	Head, Tail, RingMask, RingEntries, Flags, Dropped, Array, Resv1 uint32
+	Resv2                                                           uint64
+}
+
+type ioCqringOffsets struct {
+	Head, Tail, RingMask, RingEntries, Overflow, Cqes uint32
+	Flags                                             uint32
+	Resv1                                             uint32
+	Resv2                                             uint64
+}
+
+type ioUringParams struct {
+	SqEntries, CqEntries, Flags, SqThreadCPU, SqThreadIdle, Features uint32
+	WqFd                                                             uint32
+	Resv                                                             [3]uint32
+	SqOff                                                            ioSqringOffsets
+	CqOff                                                            ioCqringOffsets
+}
+
+// ioUringSqe mirrors struct io_uring_sqe; the trailing padding covers the
+// union fields (buf_index, rw_flags variants, etc.) we don't use.
+type ioUringSqe struct {
+	Opcode   uint8
+	Flags    uint8
+	Ioprio   uint16
+	Fd       int32
+	Off      uint64
+	Addr     uint64
+	Len      uint32
+	RwFlags  uint32
+	UserData uint64
+	Pad      [3]uint64
+}
+
+type ioUringCqe struct {
+	UserData uint64
+	Res      int32
+	Flags    uint32
+}
+
+// ioUringRing wraps the mmap'd submission/completion queues for one worker's
+// io_uring instance.
+type ioUringRing struct {
+	fd     int
+	params ioUringParams
+	sqRing []byte
+	cqRing []byte
+	sqes   []byte
+
+	sqTail  *uint32
+	sqMask  uint32
+	sqArray []uint32
+
+	cqHead, cqTail *uint32
+	cqMask         uint32
+	cqes           []ioUringCqe
+}
+
+func newIOUringRing(entries uint32) (*ioUringRing, error) {
+	var params ioUringParams
+	fd, _, errno := syscall.Syscall(sysIoUringSetup, uintptr(entries), uintptr(unsafe.Pointer(&params)), 0)
+	if errno != 0 {
+		return nil, fmt.Errorf("io_uring_setup: %v", errno)
+	}
+
+	r := &ioUringRing{fd: int(fd), params: params}
+
+	sqRingSize := int(params.SqOff.Array) + int(params.SqEntries)*4
+	cqRingSize := int(params.CqOff.Cqes) + int(params.CqEntries)*int(unsafe.Sizeof(ioUringCqe{}))
+
+	sqRing, err := syscall.Mmap(r.fd, ioUringOffSqRing, sqRingSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED|syscall.MAP_POPULATE)
+	if err != nil {
+		syscall.Close(r.fd)
+		return nil, fmt.Errorf("mmap sq ring: %v", err)
+	}
+	r.sqRing = sqRing
+
+	cqRing := sqRing
+	if params.Features&ioUringFeatSingleMmap == 0 {
+		cqRing, err = syscall.Mmap(r.fd, ioUringOffCqRing, cqRingSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED|syscall.MAP_POPULATE)
+		if err != nil {
+			syscall.Munmap(r.sqRing)
+			syscall.Close(r.fd)
+			return nil, fmt.Errorf("mmap cq ring: %v", err)
+		}
+	}
+	r.cqRing = cqRing
+
+	sqesSize := int(params.SqEntries) * int(unsafe.Sizeof(ioUringSqe{}))
+	sqes, err := syscall.Mmap(r.fd, ioUringOffSqes, sqesSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED|syscall.MAP_POPULATE)
+	if err != nil {
+		syscall.Munmap(r.sqRing)
+		if params.Features&ioUringFeatSingleMmap == 0 {
+			syscall.Munmap(r.cqRing)
+		}
+		syscall.Close(r.fd)
+		return nil, fmt.Errorf("mmap sqes: %v", err)
+	}
+	r.sqes = sqes
+
+	r.sqTail = (*uint32)(unsafe.Pointer(&r.sqRing[params.SqOff.Tail]))
+	r.sqMask = *(*uint32)(unsafe.Pointer(&r.sqRing[params.SqOff.RingMask]))
+	r.sqArray = unsafe.Slice((*uint32)(unsafe.Pointer(&r.sqRing[params.SqOff.Array])), params.SqEntries)
+
+	r.cqHead = (*uint32)(unsafe.Pointer(&r.cqRing[params.CqOff.Head]))
+	r.cqTail = (*uint32)(unsafe.Pointer(&r.cqRing[params.CqOff.Tail]))
+	r.cqMask = *(*uint32)(unsafe.Pointer(&r.cqRing[params.CqOff.RingMask]))
+	r.cqes = unsafe.Slice((*ioUringCqe)(unsafe.Pointer(&r.cqRing[params.CqOff.Cqes])), params.CqEntries)
+
+	return r, nil
+}
+
+func (r *ioUringRing) close() {
+	syscall.Munmap(r.sqes)
+	if r.params.Features&ioUringFeatSingleMmap == 0 {
+		syscall.Munmap(r.cqRing)
+	}
+	syscall.Munmap(r.sqRing)
+	syscall.Close(r.fd)
+}
+
+// submit queues a read or write SQE for fd at offset into buf, tagged with userData.
+func (r *ioUringRing) submit(fd int, offset int64, buf []byte, write bool, userData uint64) {
+	tail := atomic.LoadUint32(r.sqTail)
+	index := tail & r.sqMask
+	sqe := (*ioUringSqe)(unsafe.Pointer(&r.sqes[uintptr(index)*unsafe.Sizeof(ioUringSqe{})]))
+
+	*sqe = ioUringSqe{}
+	if write {
+		sqe.Opcode = ioUringOpWrite
+	} else {
+		sqe.Opcode = ioUringOpRead
+	}
+	sqe.Fd = int32(fd)
+	sqe.Off = uint64(offset)
+	sqe.Addr = uint64(uintptr(unsafe.Pointer(&buf[0])))
+	sqe.Len = uint32(len(buf))
+	sqe.UserData = userData
+
+	r.sqArray[index] = index
+	atomic.StoreUint32(r.sqTail, tail+1)
+}
+
+// enter submits queued SQEs and optionally waits for minComplete CQEs.
+func (r *ioUringRing) enter(toSubmit, minComplete, flags uint32) (int, error) {
+	n, _, errno := syscall.Syscall6(sysIoUringEnter, uintptr(r.fd), uintptr(toSubmit), uintptr(minComplete), uintptr(flags), 0, 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+// reap drains up to len(out) completed CQEs and returns how many were read.
+func (r *ioUringRing) reap(out []ioUringCqe) int {
+	head := atomic.LoadUint32(r.cqHead)
+	tail := atomic.LoadUint32(r.cqTail)
+
+	n := 0
+	for head != tail && n < len(out) {
+		out[n] = r.cqes[head&r.cqMask]
+		head++
+		n++
+	}
+	atomic.StoreUint32(r.cqHead, head)
+	return n
+}
+
+// blkssZget is BLKSSZGET, the ioctl that reports a block device's logical
+// sector size - the alignment O_DIRECT requires of both the I/O buffer
+// address and the offset/length of each read or write.
+const blkssZget = 0x1268
+
+// logicalBlockSize queries fd's logical sector size via BLKSSZGET, falling
+// back to 512 (the smallest size any real device uses) if fd isn't a block
+// device or the ioctl fails - e.g. a regular file opened with O_DIRECT,
+// which only requires the underlying filesystem's own alignment, typically
+// no coarser than 512 bytes.
+func logicalBlockSize(fd int) int64 {
+	var size int
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(blkssZget), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 || size <= 0 {
+		return 512
+	}
+	return int64(size)
+}
+
+// alignedBuffer returns a size-byte slice whose backing address is a
+// multiple of align, as O_DIRECT requires of the buffer passed to a
+// read/write - it over-allocates by align and slices forward to the next
+// aligned address, since make([]byte, n) makes no alignment guarantee.
+func alignedBuffer(size, align int64) []byte {
+	buf := make([]byte, size+align)
+	addr := uintptr(unsafe.Pointer(&buf[0]))
+	offset := (align - int64(addr%uintptr(align))) % align
+	return buf[offset : offset+size]
+}
+
+// workerIOUring runs the hot I/O loop for one worker goroutine using
+// io_uring, keeping queueDepth SQEs in flight against the device so real
+// queue depth no longer depends on spawning extra OS threads. Returns a
+// non-nil error if io_uring setup fails (e.g. kernel < 5.1) or an op
+// completes with an error, signalling the caller to fall back to workerSync.
+func (e *Engine) workerIOUring(file *os.File, ioSize int64, queueDepth int, isWrite bool, totalOps, totalBytes, totalLatencyNs *atomic.Int64, stopChan chan bool, deviceSize int64, hist *latencyHistogram) error {
+	if queueDepth <= 0 {
+		queueDepth = 4
+	}
+
+	ring, err := newIOUringRing(uint32(queueDepth))
+	if err != nil {
+		return err
+	}
+	defer ring.close()
+
+	fd := int(file.Fd())
+	blockSize := logicalBlockSize(fd)
+
+	buffers := make([][]byte, queueDepth)
+	for i := range buffers {
+		buffers[i] = alignedBuffer(ioSize, blockSize)
+		if isWrite {
+			rand.Read(buffers[i])
+		}
+	}
+
+	maxOffset := (deviceSize / ioSize) - 1
+	if maxOffset <= 0 {
+		maxOffset = 1
+	}
+
+	opStart := make([]time.Time, queueDepth)
+	cqes := make([]ioUringCqe, queueDepth)
+
+	const batchSize = 256
+	var localOps, localBytes, localLatencyNs int64
+
+	// Prime the ring with one SQE per slot.
+	for i := 0; i < queueDepth; i++ {
+		offset := (int64(i) % maxOffset) * ioSize
+		opStart[i] = time.Now()
+		ring.submit(fd, offset, buffers[i], isWrite, uint64(i))
+	}
+	if _, err := ring.enter(uint32(queueDepth), 0, 0); err != nil {
+		return fmt.Errorf("io_uring_enter: %v", err)
+	}
+
+	nextOffsetIdx := int64(queueDepth)
+	for {
+		select {
+		case <-stopChan:
+			if localOps > 0 {
+				totalOps.Add(localOps)
+				totalBytes.Add(localBytes)
+				totalLatencyNs.Add(localLatencyNs)
+			}
+			return nil
+		default:
+		}
+
+		if _, err := ring.enter(0, 1, ioUringEnterGetevents); err != nil {
+			return fmt.Errorf("io_uring_enter wait: %v", err)
+		}
+
+		completed := ring.reap(cqes)
+		for i := 0; i < completed; i++ {
+			cqe := cqes[i]
+			slot := cqe.UserData
+
+			if cqe.Res < 0 {
+				// A negative Res is -errno, same as a failed pread/pwrite
+				// syscall would return - most commonly EINVAL here, meaning
+				// the buffer or offset isn't aligned to the device's
+				// logical block size. Surface it instead of silently
+				// resubmitting forever and reporting near-zero throughput.
+				return fmt.Errorf("io_uring op failed: %v", syscall.Errno(-cqe.Res))
+			}
+			if cqe.Res > 0 {
+				localOps++
+				localBytes += int64(cqe.Res)
+				latency := time.Since(opStart[slot]).Nanoseconds()
+				localLatencyNs += latency
+				hist.record(latency)
+
+				if localOps >= batchSize {
+					totalOps.Add(localOps)
+					totalBytes.Add(localBytes)
+					totalLatencyNs.Add(localLatencyNs)
+					localOps, localBytes, localLatencyNs = 0, 0, 0
+				}
+			}
+
+			// Re-submit the same slot against a fresh offset to keep the ring full.
+			offset := (nextOffsetIdx % maxOffset) * ioSize
+			nextOffsetIdx++
+			opStart[slot] = time.Now()
+			ring.submit(fd, offset, buffers[slot], isWrite, slot)
+		}
+
+		if completed > 0 {
+			if _, err := ring.enter(uint32(completed), 0, 0); err != nil {
+				return fmt.Errorf("io_uring_enter resubmit: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+//go:build windows
+// +build windows
+
+package benchmark
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// waitTimeoutMs bounds each GetQueuedCompletionStatus wait so the loop can
+// check stopChan between completions instead of blocking on it forever.
+const waitTimeoutMs = 1000
+
+// waitTimeout is WAIT_TIMEOUT, the error GetQueuedCompletionStatus returns
+// when waitTimeoutMs elapses with nothing completed.
+const waitTimeout = syscall.Errno(258)
+
+// iouringWindowsSlot is one in-flight overlapped read/write: its own
+// OVERLAPPED (carrying the offset the kernel should read/write at) and
+// buffer, identified on completion by matching the *syscall.Overlapped
+// GetQueuedCompletionStatus hands back against &slot.overlapped.
+type iouringWindowsSlot struct {
+	overlapped syscall.Overlapped
+	buf        []byte
+	start      time.Time
+}
+
+// workerIOUring is io_uring's Windows counterpart, keeping queueDepth
+// overlapped ReadFile/WriteFile calls in flight against one I/O completion
+// port - the same "real queue depth without extra OS threads" goal the
+// Linux io_uring path serves. file was already opened with
+// FILE_FLAG_OVERLAPPED (device_windows.go); this is what actually drives it
+// asynchronously instead of letting every ReadFile/WriteFile block.
+func (e *Engine) workerIOUring(file *os.File, ioSize int64, queueDepth int, isWrite bool, totalOps, totalBytes, totalLatencyNs *atomic.Int64, stopChan chan bool, deviceSize int64, hist *latencyHistogram) error {
+	if queueDepth <= 0 {
+		queueDepth = 4
+	}
+
+	handle := syscall.Handle(file.Fd())
+	iocp, err := syscall.CreateIoCompletionPort(handle, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("CreateIoCompletionPort: %v", err)
+	}
+	defer syscall.CloseHandle(iocp)
+
+	slots := make([]*iouringWindowsSlot, queueDepth)
+	for i := range slots {
+		buf := make([]byte, ioSize)
+		if isWrite {
+			rand.Read(buf)
+		}
+		slots[i] = &iouringWindowsSlot{buf: buf}
+	}
+
+	maxOffset := (deviceSize / ioSize) - 1
+	if maxOffset <= 0 {
+		maxOffset = 1
+	}
+
+	submit := func(s *iouringWindowsSlot, offset int64) error {
+		s.overlapped = syscall.Overlapped{
+			Offset:     uint32(offset),
+			OffsetHigh: uint32(offset >> 32),
+		}
+		s.start = time.Now()
+
+		var done uint32
+		var opErr error
+		if isWrite {
+			opErr = syscall.WriteFile(handle, s.buf, &done, &s.overlapped)
+		} else {
+			opErr = syscall.ReadFile(handle, s.buf, &done, &s.overlapped)
+		}
+		if opErr != nil && opErr != syscall.ERROR_IO_PENDING {
+			return opErr
+		}
+		return nil
+	}
+
+	for i, s := range slots {
+		offset := (int64(i) % maxOffset) * ioSize
+		if err := submit(s, offset); err != nil {
+			return fmt.Errorf("submit: %v", err)
+		}
+	}
+	nextOffsetIdx := int64(queueDepth)
+
+	const batchSize = 256
+	var localOps, localBytes, localLatencyNs int64
+
+	for {
+		select {
+		case <-stopChan:
+			if localOps > 0 {
+				totalOps.Add(localOps)
+				totalBytes.Add(localBytes)
+				totalLatencyNs.Add(localLatencyNs)
+			}
+			return nil
+		default:
+		}
+
+		var transferred uint32
+		var overlapped *syscall.Overlapped
+		err := syscall.GetQueuedCompletionStatus(iocp, &transferred, nil, &overlapped, waitTimeoutMs)
+		if overlapped == nil {
+			if err == waitTimeout {
+				continue
+			}
+			return fmt.Errorf("GetQueuedCompletionStatus: %v", err)
+		}
+		if err != nil {
+			// The completed op itself failed (e.g. a misaligned offset on a
+			// device opened with FILE_FLAG_NO_BUFFERING) - surface it
+			// instead of silently treating it the same as success.
+			return fmt.Errorf("overlapped op failed: %v", err)
+		}
+
+		var slot *iouringWindowsSlot
+		for _, s := range slots {
+			if &s.overlapped == overlapped {
+				slot = s
+				break
+			}
+		}
+		if slot == nil {
+			return fmt.Errorf("GetQueuedCompletionStatus returned an unrecognized OVERLAPPED")
+		}
+
+		if transferred > 0 {
+			localOps++
+			localBytes += int64(transferred)
+			latency := time.Since(slot.start).Nanoseconds()
+			localLatencyNs += latency
+			hist.record(latency)
+
+			if localOps >= batchSize {
+				totalOps.Add(localOps)
+				totalBytes.Add(localBytes)
+				totalLatencyNs.Add(localLatencyNs)
+				localOps, localBytes, localLatencyNs = 0, 0, 0
+			}
+		}
+
+		offset := (nextOffsetIdx % maxOffset) * ioSize
+		nextOffsetIdx++
+		if err := submit(slot, offset); err != nil {
+			return fmt.Errorf("resubmit: %v", err)
+		}
+	}
+}
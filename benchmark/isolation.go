@@ -0,0 +1,44 @@
+package benchmark
+
+import "fmt"
+
+// isolation is an active I/O isolation scope for the duration of one
+// benchmark run. Its setIOPSCap method lets an IOPS sweep re-cap the
+// device between steps without tearing down and recreating the scope.
+type isolation interface {
+	setIOPSCap(riops, wiops int64) error
+	teardown() error
+}
+
+// hasIOMax reports whether config asks for any io.max isolation at all.
+func (c Config) hasIOMax() bool {
+	return c.IOMaxRBps != 0 || c.IOMaxWBps != 0 || c.IOMaxRIOPS != 0 || c.IOMaxWIOPS != 0
+}
+
+// isolationTargets returns every device path applyIsolation should cap: all
+// of config.Devices for a multi-device run, or just config.Device for a
+// single-device one.
+func (c Config) isolationTargets() []string {
+	if len(c.Devices) > 1 {
+		return c.Devices
+	}
+	if c.Device != "" {
+		return []string{c.Device}
+	}
+	return nil
+}
+
+// applyIsolation creates and enters a transient cgroup v2 scope capping
+// every device in config.isolationTargets() per config.IOMax*, returning
+// nil if config asks for no caps. Callers must call teardown() on a
+// non-nil result once the run completes.
+func applyIsolation(config Config) (isolation, error) {
+	if !config.hasIOMax() {
+		return nil, nil
+	}
+	iso, err := newCgroupIsolation(config)
+	if err != nil {
+		return nil, fmt.Errorf("io.max isolation: %v", err)
+	}
+	return iso, nil
+}
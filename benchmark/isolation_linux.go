@@ -0,0 +1,135 @@
+//go:build linux
+// +build linux
+
+package benchmark
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cgroupRoot is where the distro mounts the unified cgroup v2 hierarchy.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupIsolation is a transient cgroup v2 scope created under cgroupRoot
+// for the lifetime of one benchmark run, with every target device's io.max
+// set to the caps from Config.IOMax* - io.max takes one "<major>:<minor> ..."
+// line per device, so a multi-device run caps all of them from inside the
+// same scope rather than just the first.
+type cgroupIsolation struct {
+	path    string
+	majMins []string
+}
+
+// newCgroupIsolation creates /sys/fs/cgroup/4corners-<pid>, writes io.max
+// for every device in config.isolationTargets()'s major:minor, and moves
+// the current process (all of its OS threads, so every worker goroutine)
+// into it via cgroup.procs.
+func newCgroupIsolation(config Config) (isolation, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("%s is not a cgroup v2 mount: %v", cgroupRoot, err)
+	}
+
+	targets := config.isolationTargets()
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("io.max isolation requested but no device is configured")
+	}
+
+	majMins := make([]string, len(targets))
+	for i, dev := range targets {
+		majMin, err := deviceMajMin(dev)
+		if err != nil {
+			return nil, err
+		}
+		majMins[i] = majMin
+	}
+
+	path := filepath.Join(cgroupRoot, fmt.Sprintf("4corners-%d", os.Getpid()))
+	if err := os.Mkdir(path, 0755); err != nil {
+		return nil, fmt.Errorf("creating %s: %v (cgroup v2 isolation needs CAP_SYS_ADMIN)", path, err)
+	}
+
+	iso := &cgroupIsolation{path: path, majMins: majMins}
+	if err := iso.setIOMax(config.IOMaxRBps, config.IOMaxWBps, config.IOMaxRIOPS, config.IOMaxWIOPS); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	if err := writeCgroupFile(path, "cgroup.procs", strconv.Itoa(os.Getpid())); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("moving pid into %s: %v", path, err)
+	}
+
+	return iso, nil
+}
+
+// setIOMax writes one io.max line per target device with the given caps;
+// zero means "max" (unrestricted) for that dimension, per the cgroup v2
+// io.max file format: "<major>:<minor> rbps=N wbps=N riops=N wiops=N".
+func (c *cgroupIsolation) setIOMax(rbps, wbps, riops, wiops int64) error {
+	caps := fmt.Sprintf("rbps=%s wbps=%s riops=%s wiops=%s", capStr(rbps), capStr(wbps), capStr(riops), capStr(wiops))
+	for _, majMin := range c.majMins {
+		line := majMin + " " + caps
+		if err := writeCgroupFile(c.path, "io.max", line); err != nil {
+			return fmt.Errorf("writing io.max for %s: %v", majMin, err)
+		}
+	}
+	return nil
+}
+
+// setIOPSCap re-caps just riops/wiops, leaving bandwidth unrestricted;
+// used between steps of an IOPS sweep.
+func (c *cgroupIsolation) setIOPSCap(riops, wiops int64) error {
+	return c.setIOMax(0, 0, riops, wiops)
+}
+
+// teardown moves the current process back to the root cgroup - a cgroup
+// directory can't be removed while it still lists processes in
+// cgroup.procs - and removes the transient scope.
+func (c *cgroupIsolation) teardown() error {
+	if err := writeCgroupFile(cgroupRoot, "cgroup.procs", strconv.Itoa(os.Getpid())); err != nil {
+		return fmt.Errorf("moving pid back to root cgroup: %v", err)
+	}
+	return os.Remove(c.path)
+}
+
+func writeCgroupFile(dir, name, content string) error {
+	return os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+}
+
+func capStr(v int64) string {
+	if v <= 0 {
+		return "max"
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// deviceMajMin resolves devicePath's block device major:minor via stat(2),
+// the same st_rdev decomposition as <linux/kdev_t.h>'s MAJOR/MINOR macros.
+func deviceMajMin(devicePath string) (string, error) {
+	path := devicePath
+	if idx := strings.Index(devicePath, "://"); idx >= 0 {
+		scheme := devicePath[:idx]
+		if scheme != "file" {
+			return "", fmt.Errorf("io.max isolation requires a raw block device target, not %q", devicePath)
+		}
+		path = devicePath[idx+3:]
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return "", fmt.Errorf("stat %s: %v", path, err)
+	}
+	if st.Mode&syscall.S_IFMT != syscall.S_IFBLK {
+		return "", fmt.Errorf("%s is not a block device; io.max isolation requires a raw device target", path)
+	}
+
+	rdev := uint64(st.Rdev)
+	major := (rdev>>8)&0xfff | (rdev>>32)&^uint64(0xfff)
+	minor := rdev&0xff | (rdev>>12)&^uint64(0xff)
+	return fmt.Sprintf("%d:%d", major, minor), nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+// +build !linux
+
+package benchmark
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// newCgroupIsolation reports that io.max isolation is Linux-only; cgroup
+// v2 has no equivalent on other platforms.
+func newCgroupIsolation(config Config) (isolation, error) {
+	return nil, fmt.Errorf("io.max isolation requires Linux cgroup v2 (unsupported on %s)", runtime.GOOS)
+}
@@ -0,0 +1,376 @@
+package benchmark
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Pattern selects how a JobSpec walks the device.
+type Pattern string
+
+const (
+	PatternRandom     Pattern = "random"
+	PatternSequential Pattern = "sequential"
+)
+
+// JobSpec describes a single fio-style workload: a block size, a read/write
+// mix, and the thread/queue-depth/duration knobs that control how hard it
+// drives the device. Unlike the four fixed tests in RunBenchmark, a JobSpec
+// can mix reads and writes within the same run via RWMix.
+type JobSpec struct {
+	Name          string
+	RWMix         int // 0-100, percent of ops that are reads
+	Pattern       Pattern
+	BlockSize     string // e.g. "4k", "128k"
+	Threads       int
+	QueueDepth    int
+	Duration      int // seconds
+	OffsetAlign   int64
+	SizeLimit     int64 // 0 means use the full device
+	NumaNode      int
+	RampUpSeconds int
+}
+
+// JobResult is the outcome of running a single JobSpec. ReadLatency/
+// WriteLatency carry the same percentile/histogram breakdown as the fixed
+// four tests in Results (see engine.go's RunSingleTest), rather than a bare
+// average, so a job file run is comparable to one of those on a p99 basis.
+type JobResult struct {
+	Name                string
+	ReadThroughputMBps  float64
+	WriteThroughputMBps float64
+	ReadIOPS            float64
+	WriteIOPS           float64
+	ReadLatency         LatencyStats
+	WriteLatency        LatencyStats
+}
+
+// RunJobs runs each JobSpec against devicePath in series.
+func (e *Engine) RunJobs(devicePath string, jobs []JobSpec, progressCallback func(string)) ([]JobResult, error) {
+	groups := make([][]JobSpec, len(jobs))
+	for i, job := range jobs {
+		groups[i] = []JobSpec{job}
+	}
+	return e.RunJobGroups(devicePath, groups, progressCallback)
+}
+
+// RunJobGroups runs each group of JobSpecs concurrently (all jobs in a group
+// start together) while groups themselves run one after another, mirroring
+// fio's ability to mix stonewalled and parallel jobs in one job file.
+func (e *Engine) RunJobGroups(devicePath string, groups [][]JobSpec, progressCallback func(string)) ([]JobResult, error) {
+	e.Reset()
+
+	var results []JobResult
+	for _, group := range groups {
+		if e.stopped {
+			return results, fmt.Errorf("benchmark stopped by user")
+		}
+
+		groupResults := make([]JobResult, len(group))
+		var wg sync.WaitGroup
+		errs := make([]error, len(group))
+
+		for i, job := range group {
+			wg.Add(1)
+			go func(i int, job JobSpec) {
+				defer wg.Done()
+				progressCallback(fmt.Sprintf("Running job %q...", job.Name))
+				res, err := e.runJob(devicePath, job, progressCallback)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				groupResults[i] = res
+			}(i, job)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return results, err
+			}
+		}
+		results = append(results, groupResults...)
+	}
+
+	return results, nil
+}
+
+func (e *Engine) runJob(devicePath string, job JobSpec, progressCallback func(string)) (JobResult, error) {
+	ioSizeBytes, err := ParseSize(job.BlockSize)
+	if err != nil {
+		return JobResult{}, fmt.Errorf("job %q: invalid block size: %v", job.Name, err)
+	}
+
+	threads := job.Threads
+	if threads <= 0 {
+		threads = 1
+	}
+	queueDepth := job.QueueDepth
+	if queueDepth <= 0 {
+		queueDepth = 4
+	}
+
+	var totalReadOps, totalReadBytes atomic.Int64
+	var totalWriteOps, totalWriteBytes atomic.Int64
+	readHists := make([]*latencyHistogram, threads)
+	writeHists := make([]*latencyHistogram, threads)
+	var wg sync.WaitGroup
+	stopChan := make(chan bool)
+	workerErrors := make(chan error, threads)
+
+	for i := 0; i < threads; i++ {
+		readHists[i] = &latencyHistogram{}
+		writeHists[i] = &latencyHistogram{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := e.mixedWorker(devicePath, job, ioSizeBytes, queueDepth, &totalReadOps, &totalReadBytes, readHists[i], &totalWriteOps, &totalWriteBytes, writeHists[i], stopChan); err != nil {
+				select {
+				case workerErrors <- err:
+				default:
+				}
+			}
+		}(i)
+	}
+
+	duration := job.Duration
+	if duration <= 0 {
+		duration = 60
+	}
+	timer := time.NewTimer(time.Duration(job.RampUpSeconds+duration) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-e.stopChan:
+		progressCallback(fmt.Sprintf("Job %q stopped by user", job.Name))
+	}
+
+	close(stopChan)
+	wg.Wait()
+	close(workerErrors)
+	if err := <-workerErrors; err != nil {
+		return JobResult{}, fmt.Errorf("job %q: %v", job.Name, err)
+	}
+
+	elapsed := float64(duration)
+	result := JobResult{Name: job.Name}
+
+	readHist := &latencyHistogram{}
+	for _, h := range readHists {
+		readHist.merge(h)
+	}
+	writeHist := &latencyHistogram{}
+	for _, h := range writeHists {
+		writeHist.merge(h)
+	}
+
+	if ops := totalReadOps.Load(); ops > 0 {
+		result.ReadIOPS = float64(ops) / elapsed
+		result.ReadThroughputMBps = (float64(totalReadBytes.Load()) / elapsed) / (1024 * 1024)
+		result.ReadLatency = readHist.stats()
+	}
+	if ops := totalWriteOps.Load(); ops > 0 {
+		result.WriteIOPS = float64(ops) / elapsed
+		result.WriteThroughputMBps = (float64(totalWriteBytes.Load()) / elapsed) / (1024 * 1024)
+		result.WriteLatency = writeHist.stats()
+	}
+
+	progressCallback(fmt.Sprintf("Job %q: %.2f/%.2f MB/s R/W | %.0f/%.0f IOPS R/W | %.2f/%.2f ms p99 R/W", job.Name,
+		result.ReadThroughputMBps, result.WriteThroughputMBps, result.ReadIOPS, result.WriteIOPS,
+		result.ReadLatency.P99Ms, result.WriteLatency.P99Ms))
+
+	return result, nil
+}
+
+// mixedWorker issues reads and writes against a single JobSpec via the
+// Target abstraction, choosing the op type per-iteration according to
+// job.RWMix and the offset per job.Pattern. It always drives Target through
+// the portable synchronous ReadAt/WriteAt path rather than engine.go's
+// io_uring fast path, since that path is built around a fixed read-or-write
+// direction per worker and RWMix mixes both within one.
+func (e *Engine) mixedWorker(devicePath string, job JobSpec, ioSize int64, queueDepth int, readOps, readBytes *atomic.Int64, readHist *latencyHistogram, writeOps, writeBytes *atomic.Int64, writeHist *latencyHistogram, stopChan chan bool) error {
+	target, err := NewTarget(devicePath)
+	if err != nil {
+		return err
+	}
+	if err := target.Open(job.RWMix < 100); err != nil {
+		return err
+	}
+	defer target.Close()
+
+	deviceSize, err := target.Size()
+	if err != nil {
+		return fmt.Errorf("failed to get target size: %v", err)
+	}
+	if job.SizeLimit > 0 && job.SizeLimit < deviceSize {
+		deviceSize = job.SizeLimit
+	}
+
+	align := job.OffsetAlign
+	if align <= 0 {
+		align = ioSize
+	}
+	maxBlocks := deviceSize / align
+	if maxBlocks <= 0 {
+		maxBlocks = 1
+	}
+
+	buffer := make([]byte, ioSize)
+	rand.Read(buffer)
+
+	var seqOffset int64
+	loopCount := 0
+	for {
+		if loopCount%queueDepth == 0 {
+			select {
+			case <-stopChan:
+				return nil
+			default:
+			}
+		}
+		loopCount++
+
+		var offset int64
+		if job.Pattern == PatternSequential {
+			offset = (seqOffset % maxBlocks) * align
+			seqOffset++
+		} else {
+			offset = (rand.Int63() % maxBlocks) * align
+		}
+
+		isRead := rand.Intn(100) < job.RWMix
+		start := time.Now()
+
+		var n int
+		if isRead {
+			n, err = target.ReadAt(buffer, offset)
+		} else {
+			n, err = target.WriteAt(buffer, offset)
+		}
+		if err != nil || n <= 0 {
+			continue
+		}
+		latency := time.Since(start).Nanoseconds()
+
+		if isRead {
+			readOps.Add(1)
+			readBytes.Add(int64(n))
+			readHist.record(latency)
+		} else {
+			writeOps.Add(1)
+			writeBytes.Add(int64(n))
+			writeHist.record(latency)
+		}
+	}
+}
+
+// LoadJobSpecs parses a minimal subset of fio's INI job-file format, enough
+// to reproduce the block size / iodepth / numjobs / runtime / rw knobs of a
+// typical third-party fio job file. Each [section] becomes one JobSpec named
+// after the section.
+func LoadJobSpecs(path string) ([]JobSpec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job file: %v", err)
+	}
+	defer f.Close()
+
+	var jobs []JobSpec
+	var current *JobSpec
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if current != nil {
+				jobs = append(jobs, *current)
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			current = &JobSpec{Name: name, RWMix: 0, Pattern: PatternRandom, BlockSize: "4k", Threads: 1, QueueDepth: 4, Duration: 60}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "rw", "readwrite":
+			switch value {
+			case "read", "randread":
+				current.RWMix = 100
+			case "write", "randwrite":
+				current.RWMix = 0
+			case "rw", "readwrite", "randrw":
+				current.RWMix = 50
+			}
+			if strings.HasPrefix(value, "rand") {
+				current.Pattern = PatternRandom
+			} else {
+				current.Pattern = PatternSequential
+			}
+		case "rwmixread":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.RWMix = v
+			}
+		case "bs", "blocksize":
+			current.BlockSize = value
+		case "numjobs":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.Threads = v
+			}
+		case "iodepth":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.QueueDepth = v
+			}
+		case "runtime":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.Duration = v
+			}
+		case "ramp_time":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.RampUpSeconds = v
+			}
+		case "size":
+			if v, err := ParseSize(value); err == nil {
+				current.SizeLimit = v
+			}
+		case "offset_align", "ioalign":
+			if v, err := ParseSize(value); err == nil {
+				current.OffsetAlign = v
+			}
+		case "numa_node":
+			if v, err := strconv.Atoi(value); err == nil {
+				current.NumaNode = v
+			}
+		}
+	}
+	if current != nil {
+		jobs = append(jobs, *current)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read job file: %v", err)
+	}
+
+	return jobs, nil
+}
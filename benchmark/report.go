@@ -1,38 +1,12 @@
 package benchmark
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
-	"time"
-)
+	"sort"
 
-func (r *Results) SaveReport(outputDir string) error {
-	timestamp := time.Now().Format("20060102-150405")
-	
-	// Save JSON report
-	jsonPath := filepath.Join(outputDir, fmt.Sprintf("4corners-report-%s.json", timestamp))
-	jsonData, err := json.MarshalIndent(r, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %v", err)
-	}
-	
-	err = os.WriteFile(jsonPath, jsonData, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write JSON report: %v", err)
-	}
-	
-	// Save text report
-	textPath := filepath.Join(outputDir, fmt.Sprintf("4corners-report-%s.txt", timestamp))
-	textReport := r.GenerateTextReport()
-	err = os.WriteFile(textPath, []byte(textReport), 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write text report: %v", err)
-	}
-	
-	return nil
-}
+	"4corners/device/smart"
+	"4corners/sysstat"
+)
 
 func (r *Results) GenerateTextReport() string {
 	report := "========================================\n"
@@ -40,13 +14,13 @@ func (r *Results) GenerateTextReport() string {
 	report += "========================================\n\n"
 	report += fmt.Sprintf("Test Date: %s\n", r.TestDate.Format("2006-01-02 15:04:05"))
 	report += fmt.Sprintf("Device: %s\n\n", r.Config.Device)
-	
+
 	report += "Configuration:\n"
 	report += fmt.Sprintf("  Read Throughput IO Size: %s\n", r.Config.ReadTPIOSize)
 	report += fmt.Sprintf("  Write Throughput IO Size: %s\n", r.Config.WriteTPIOSize)
 	report += fmt.Sprintf("  Read IOPS IO Size: %s\n", r.Config.ReadIOPSIOSize)
 	report += fmt.Sprintf("  Write IOPS IO Size: %s\n\n", r.Config.WriteIOPSIOSize)
-	
+
 	report += "Results:\n"
 	report += "========================================\n"
 	report += "Read Throughput Test:\n"
@@ -56,8 +30,15 @@ func (r *Results) GenerateTextReport() string {
 	}
 	report += fmt.Sprintf("  Throughput: %10.2f MB/s\n", r.ReadThroughputMBps)
 	report += fmt.Sprintf("  IOPS:       %10.0f IOPS\n", r.ReadThroughputIOPS)
-	report += fmt.Sprintf("  Latency:    %10.2f ms\n\n", r.ReadTPLatencyMs)
-	
+	report += fmt.Sprintf("  Latency:    %10.2f ms (avg)\n", r.ReadTPLatencyMs)
+	report += fmt.Sprintf("              %10.2f ms (p50)  %10.2f ms (p95)  %10.2f ms (p99)  %10.2f ms (p99.9)  %10.2f ms (max)\n",
+		r.ReadTPLatencyP50Ms, r.ReadTPLatencyP95Ms, r.ReadTPLatencyP99Ms, r.ReadTPLatencyP999Ms, r.ReadTPLatencyMaxMs)
+	report += fmt.Sprintf("              %10.2f ms (min)  %10.2f ms (p99.99)  %10.2f ms (stdev)\n",
+		r.ReadTPLatencyMinMs, r.ReadTPLatencyP9999Ms, r.ReadTPLatencyStdevMs)
+	report += latencySparklineLine(r.ReadTPLatencySparkline)
+	report += hostStatsLine(r.HostStats["Read Throughput"], r.ReadThroughputMBps)
+	report += smartStatsLines(r.SMART["Read Throughput"])
+
 	report += "Write Throughput Test:\n"
 	if r.WriteTPThreads > 0 {
 		report += fmt.Sprintf("  Threads:    %10d\n", r.WriteTPThreads)
@@ -65,8 +46,15 @@ func (r *Results) GenerateTextReport() string {
 	}
 	report += fmt.Sprintf("  Throughput: %10.2f MB/s\n", r.WriteThroughputMBps)
 	report += fmt.Sprintf("  IOPS:       %10.0f IOPS\n", r.WriteThroughputIOPS)
-	report += fmt.Sprintf("  Latency:    %10.2f ms\n\n", r.WriteTPLatencyMs)
-	
+	report += fmt.Sprintf("  Latency:    %10.2f ms (avg)\n", r.WriteTPLatencyMs)
+	report += fmt.Sprintf("              %10.2f ms (p50)  %10.2f ms (p95)  %10.2f ms (p99)  %10.2f ms (p99.9)  %10.2f ms (max)\n",
+		r.WriteTPLatencyP50Ms, r.WriteTPLatencyP95Ms, r.WriteTPLatencyP99Ms, r.WriteTPLatencyP999Ms, r.WriteTPLatencyMaxMs)
+	report += fmt.Sprintf("              %10.2f ms (min)  %10.2f ms (p99.99)  %10.2f ms (stdev)\n",
+		r.WriteTPLatencyMinMs, r.WriteTPLatencyP9999Ms, r.WriteTPLatencyStdevMs)
+	report += latencySparklineLine(r.WriteTPLatencySparkline)
+	report += hostStatsLine(r.HostStats["Write Throughput"], r.WriteThroughputMBps)
+	report += smartStatsLines(r.SMART["Write Throughput"])
+
 	report += "Read IOPS Test:\n"
 	if r.ReadIOPSThreads > 0 {
 		report += fmt.Sprintf("  Threads:    %10d\n", r.ReadIOPSThreads)
@@ -74,8 +62,15 @@ func (r *Results) GenerateTextReport() string {
 	}
 	report += fmt.Sprintf("  Throughput: %10.2f MB/s\n", r.ReadIOPSThroughputMBps)
 	report += fmt.Sprintf("  IOPS:       %10.0f IOPS\n", r.ReadIOPS)
-	report += fmt.Sprintf("  Latency:    %10.2f ms\n\n", r.ReadIOPSLatencyMs)
-	
+	report += fmt.Sprintf("  Latency:    %10.2f ms (avg)\n", r.ReadIOPSLatencyMs)
+	report += fmt.Sprintf("              %10.2f ms (p50)  %10.2f ms (p95)  %10.2f ms (p99)  %10.2f ms (p99.9)  %10.2f ms (max)\n",
+		r.ReadIOPSLatencyP50Ms, r.ReadIOPSLatencyP95Ms, r.ReadIOPSLatencyP99Ms, r.ReadIOPSLatencyP999Ms, r.ReadIOPSLatencyMaxMs)
+	report += fmt.Sprintf("              %10.2f ms (min)  %10.2f ms (p99.99)  %10.2f ms (stdev)\n",
+		r.ReadIOPSLatencyMinMs, r.ReadIOPSLatencyP9999Ms, r.ReadIOPSLatencyStdevMs)
+	report += latencySparklineLine(r.ReadIOPSLatencySparkline)
+	report += hostStatsLine(r.HostStats["Read IOPS"], r.ReadIOPSThroughputMBps)
+	report += smartStatsLines(r.SMART["Read IOPS"])
+
 	report += "Write IOPS Test:\n"
 	if r.WriteIOPSThreads > 0 {
 		report += fmt.Sprintf("  Threads:    %10d\n", r.WriteIOPSThreads)
@@ -83,8 +78,113 @@ func (r *Results) GenerateTextReport() string {
 	}
 	report += fmt.Sprintf("  Throughput: %10.2f MB/s\n", r.WriteIOPSThroughputMBps)
 	report += fmt.Sprintf("  IOPS:       %10.0f IOPS\n", r.WriteIOPS)
-	report += fmt.Sprintf("  Latency:    %10.2f ms\n", r.WriteIOPSLatencyMs)
+	report += fmt.Sprintf("  Latency:    %10.2f ms (avg)\n", r.WriteIOPSLatencyMs)
+	report += fmt.Sprintf("              %10.2f ms (p50)  %10.2f ms (p95)  %10.2f ms (p99)  %10.2f ms (p99.9)  %10.2f ms (max)\n",
+		r.WriteIOPSLatencyP50Ms, r.WriteIOPSLatencyP95Ms, r.WriteIOPSLatencyP99Ms, r.WriteIOPSLatencyP999Ms, r.WriteIOPSLatencyMaxMs)
+	report += fmt.Sprintf("              %10.2f ms (min)  %10.2f ms (p99.99)  %10.2f ms (stdev)\n",
+		r.WriteIOPSLatencyMinMs, r.WriteIOPSLatencyP9999Ms, r.WriteIOPSLatencyStdevMs)
+	report += latencySparklineLine(r.WriteIOPSLatencySparkline)
+	report += hostStatsLine(r.HostStats["Write IOPS"], r.WriteIOPSThroughputMBps)
+	report += smartStatsLines(r.SMART["Write IOPS"])
+	report += latencyCurveLines(r.LatencyCurve)
+	report += perDeviceLines(r.PerDevice)
 	report += "========================================\n"
-	
+
 	return report
 }
+
+// perDeviceLines formats the per-device throughput/IOPS/latency breakdown
+// for a multi-device run, or an empty string on a single-device run (where
+// PerDevice is nil). The headline numbers above already sum across devices;
+// this section is where an individual slow array member shows up.
+func perDeviceLines(perDevice map[string]*Results) string {
+	if len(perDevice) == 0 {
+		return ""
+	}
+
+	devices := make([]string, 0, len(perDevice))
+	for device := range perDevice {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	lines := "Per-Device Results:\n"
+	for _, device := range devices {
+		r := perDevice[device]
+		lines += fmt.Sprintf("  %s:\n", device)
+		lines += fmt.Sprintf("    Read Throughput:  %8.2f MB/s | %8.0f IOPS | %6.2f ms (p99)\n", r.ReadThroughputMBps, r.ReadThroughputIOPS, r.ReadTPLatencyP99Ms)
+		lines += fmt.Sprintf("    Write Throughput: %8.2f MB/s | %8.0f IOPS | %6.2f ms (p99)\n", r.WriteThroughputMBps, r.WriteThroughputIOPS, r.WriteTPLatencyP99Ms)
+		lines += fmt.Sprintf("    Read IOPS:        %8.2f MB/s | %8.0f IOPS | %6.2f ms (p99)\n", r.ReadIOPSThroughputMBps, r.ReadIOPS, r.ReadIOPSLatencyP99Ms)
+		lines += fmt.Sprintf("    Write IOPS:       %8.2f MB/s | %8.0f IOPS | %6.2f ms (p99)\n", r.WriteIOPSThroughputMBps, r.WriteIOPS, r.WriteIOPSLatencyP99Ms)
+	}
+	return lines + "\n"
+}
+
+// latencyCurveLines formats the io.max isolation sweep as a
+// target/achieved-IOPS-vs-latency table, or an empty string if no sweep
+// ran (Config.IOMaxRIOPS and IOMaxWIOPS both unset).
+func latencyCurveLines(curve []LatencyCurvePoint) string {
+	if len(curve) == 0 {
+		return ""
+	}
+
+	lines := "io.max Latency Curve:\n"
+	lines += "  Target IOPS  Achieved IOPS  p50 (ms)  p99 (ms)\n"
+	for _, p := range curve {
+		lines += fmt.Sprintf("  %11d  %13.0f  %8.2f  %8.2f\n", p.TargetIOPS, p.AchievedIOPS, p.LatencyP50Ms, p.LatencyP99Ms)
+	}
+	return lines + "\n"
+}
+
+// latencySparklineLine formats a test's latency distribution sparkline, or
+// an empty string if no samples were recorded.
+func latencySparklineLine(sparkline string) string {
+	if sparkline == "" {
+		return ""
+	}
+	return fmt.Sprintf("  Latency Histogram: %s\n", sparkline)
+}
+
+// hostStatsLine formats the host-context summary line for a test's sysstat
+// samples, or an empty string if none were collected. ownMBps is the
+// test's own measured throughput, used to separate kernel-observed disk
+// traffic this run caused from traffic other processes caused.
+func hostStatsLine(samples []sysstat.Sample, ownMBps float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+
+	summary := sysstat.Summarize(samples, ownMBps)
+	if !summary.DiskAvailable {
+		return fmt.Sprintf("  Host:       CPU %.0f%% (p95 %.0f%%, iowait %.0f%%)\n\n", summary.AvgCPUPercent, summary.P95CPUPercent, summary.AvgIOWaitPercent)
+	}
+	line := fmt.Sprintf("  Host:       CPU %.0f%% (p95 %.0f%%, iowait %.0f%%), kernel observed avg qd %.1f, kernel IOPS %.0f\n",
+		summary.AvgCPUPercent, summary.P95CPUPercent, summary.AvgIOWaitPercent, summary.AvgQueueDepth, summary.KernelIOPS)
+	if summary.OtherProcessMBps > 0.01 {
+		line += fmt.Sprintf("              %.2f MB/s of kernel disk traffic attributed to other processes (possible noisy neighbor)\n", summary.OtherProcessMBps)
+	}
+	return line + "\n"
+}
+
+// smartStatsLines formats a test's SMART before/after/delta rows, or an
+// empty string if no snapshots were taken (Config.SMART off) or the device
+// didn't answer either snapshot.
+func smartStatsLines(stats SMARTStats) string {
+	if !stats.Before.Available || !stats.After.Available {
+		return ""
+	}
+
+	delta := smart.Diff(stats.Before, stats.After)
+	lines := "  SMART:      Before                  After                   Delta\n"
+	lines += fmt.Sprintf("    Temp:     %8.1f C              %8.1f C              %+.1f C\n",
+		stats.Before.TemperatureC, stats.After.TemperatureC, delta.TemperatureC)
+	lines += fmt.Sprintf("    Power-On: %8d hours          %8d hours          %+d hours\n",
+		stats.Before.PowerOnHours, stats.After.PowerOnHours, delta.PowerOnHours)
+	lines += fmt.Sprintf("    Wear:     %8.1f%%               %8.1f%%               %+.1f%%\n",
+		stats.Before.PercentageUsed, stats.After.PercentageUsed, delta.PercentageUsed)
+	lines += fmt.Sprintf("    Realloc:  %8d                %8d                %+d\n",
+		stats.Before.ReallocatedSectors, stats.After.ReallocatedSectors, delta.ReallocatedSectors)
+	lines += fmt.Sprintf("    Media Errs: %6d              %8d                %+d\n\n",
+		stats.Before.MediaErrors, stats.After.MediaErrors, delta.MediaErrors)
+	return lines
+}
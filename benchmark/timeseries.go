@@ -0,0 +1,85 @@
+package benchmark
+
+import "sync"
+
+// Sample is one time-series point for a single phase - the per-second
+// throughput/IOPS/latency-percentile snapshot a TimeSeries records from each
+// EventSample.
+type Sample struct {
+	T      int64   `json:"t"` // unix millis, from the source Event's Timestamp
+	MBps   float64 `json:"mbps"`
+	IOPS   float64 `json:"iops"`
+	P50Ms  float64 `json:"p50"`
+	P95Ms  float64 `json:"p95"`
+	P99Ms  float64 `json:"p99"`
+	P999Ms float64 `json:"p999"`
+}
+
+// TimeSeries keeps a rolling per-phase history of benchmark samples, so a
+// viewer connecting mid-run (a browser opening /ws/stream) can be caught up
+// on recent history instead of starting from a blank chart. Capacity bounds
+// memory for a long-running soak test; once a phase's buffer is full, the
+// oldest sample is dropped to make room for the newest.
+type TimeSeries struct {
+	capacity int
+
+	mu     sync.Mutex
+	phases map[string][]Sample
+}
+
+// NewTimeSeries creates a TimeSeries that retains up to capacity samples per
+// phase (readTP/writeTP/readIOPS/writeIOPS, or a device-qualified phase on a
+// multi-device run).
+func NewTimeSeries(capacity int) *TimeSeries {
+	return &TimeSeries{capacity: capacity, phases: make(map[string][]Sample)}
+}
+
+// Record appends ev to its phase's ring buffer. Non-sample events (phase
+// boundaries, errors) carry no per-second metrics and are ignored.
+func (ts *TimeSeries) Record(ev Event) {
+	if ev.Type != EventSample {
+		return
+	}
+
+	s := Sample{
+		T:      ev.Timestamp.UnixMilli(),
+		MBps:   ev.MBps,
+		IOPS:   ev.IOPS,
+		P50Ms:  ev.LatP50Ms,
+		P95Ms:  ev.LatP95Ms,
+		P99Ms:  ev.LatP99Ms,
+		P999Ms: ev.LatP999Ms,
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	buf := append(ts.phases[ev.Phase], s)
+	if len(buf) > ts.capacity {
+		buf = buf[len(buf)-ts.capacity:]
+	}
+	ts.phases[ev.Phase] = buf
+}
+
+// Reset clears every phase's history, called at the start of each new run so
+// a prior run's samples can't bleed into the next snapshot.
+func (ts *TimeSeries) Reset() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.phases = make(map[string][]Sample)
+}
+
+// Snapshot returns a copy of every phase's current sample history, safe for
+// a caller to marshal or range over without holding TimeSeries' lock.
+func (ts *TimeSeries) Snapshot() map[string][]Sample {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	out := make(map[string][]Sample, len(ts.phases))
+	for phase, samples := range ts.phases {
+		cp := make([]Sample, len(samples))
+		copy(cp, samples)
+		out[phase] = cp
+	}
+	return out
+}
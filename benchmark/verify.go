@@ -0,0 +1,279 @@
+package benchmark
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"4corners/sysstat"
+)
+
+// maxVerifyErrors caps how many individual mismatches Results.VerifyErrors
+// records; a truly broken device could otherwise produce one mismatch per
+// block.
+const maxVerifyErrors = 100
+
+// verifyMagic identifies a 4Corners verify-pattern block, so a stray block
+// left over from a prior un-related write is distinguishable from real
+// corruption (both still surface as a CRC mismatch either way).
+const verifyMagic = 0x34434f52 // "4COR"
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// VerifyError records a single pattern mismatch found during a Verify run.
+type VerifyError struct {
+	Offset   int64
+	Expected uint32
+	Actual   uint32
+}
+
+// verifyPattern deterministically fills buf with the write pattern for the
+// block at offset: an 8-byte header of {magic, offset} (truncated to the
+// first 8 bytes written) followed by CRC32C(offset||seed) tiled across the
+// remainder of the block. Because the pattern is a pure function of offset
+// and seed, a reader can regenerate the expected bytes without any state
+// shared from the writer.
+func verifyPattern(buf []byte, offset int64, seed int64) {
+	if len(buf) < 8 {
+		return
+	}
+	binary.BigEndian.PutUint32(buf[0:4], verifyMagic)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(offset))
+
+	var key [16]byte
+	binary.BigEndian.PutUint64(key[0:8], uint64(offset))
+	binary.BigEndian.PutUint64(key[8:16], uint64(seed))
+	tile := crc32.Checksum(key[:], crc32cTable)
+
+	for i := 8; i+4 <= len(buf); i += 4 {
+		binary.BigEndian.PutUint32(buf[i:i+4], tile)
+	}
+}
+
+// verifyBlock regenerates the expected pattern for offset and compares its
+// CRC32C against buf's, so a mismatch is reported as a pair of checksums
+// rather than a full block diff.
+func verifyBlock(buf []byte, offset int64, seed int64) (ok bool, expected, actual uint32) {
+	want := make([]byte, len(buf))
+	verifyPattern(want, offset, seed)
+	return crc32.Checksum(want, crc32cTable) == crc32.Checksum(buf, crc32cTable),
+		crc32.Checksum(want, crc32cTable), crc32.Checksum(buf, crc32cTable)
+}
+
+// recordVerifyError appends a mismatch to results.VerifyErrors, dropping it
+// once maxVerifyErrors have already been recorded.
+func recordVerifyError(mu *sync.Mutex, results *Results, offset int64, expected, actual uint32) {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(results.VerifyErrors) < maxVerifyErrors {
+		results.VerifyErrors = append(results.VerifyErrors, VerifyError{Offset: offset, Expected: expected, Actual: actual})
+	}
+}
+
+// RunVerify runs a data-integrity check against config.Device instead of a
+// throughput/IOPS test. See Config.Verify and Config.VerifyMode for the two
+// sub-modes: write-then-read populates the whole target with the pattern
+// and then re-checks it; inline has each worker alternate a write and a
+// read-back of the same offset for VerifyDuration seconds.
+func (e *Engine) RunVerify(config Config, progressCallback func(string)) (*Results, error) {
+	e.Reset()
+
+	ioSize, err := ParseSize(config.VerifyIOSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verify IO size: %v", err)
+	}
+
+	threads := config.VerifyThreads
+	if threads <= 0 {
+		threads = 1
+	}
+
+	seed := time.Now().UnixNano()
+	results := &Results{
+		TestDate:   time.Now(),
+		Config:     config,
+		HostStats:  make(map[string][]sysstat.Sample),
+		VerifyMode: config.VerifyMode,
+		VerifySeed: seed,
+	}
+
+	target, err := NewTarget(config.Device)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.Open(true); err != nil {
+		return nil, fmt.Errorf("failed to open target: %v", err)
+	}
+	defer target.Close()
+
+	size, err := target.Size()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target size: %v", err)
+	}
+
+	if config.VerifyMode == "inline" {
+		err = e.verifyInline(target, ioSize, threads, config.VerifyDuration, seed, size, results, progressCallback)
+	} else {
+		err = e.verifyWriteThenRead(target, ioSize, threads, seed, size, results, progressCallback)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// verifyBlockOffsets returns the ioSize-aligned block offsets covering the
+// first size bytes of the target.
+func verifyBlockOffsets(ioSize, size int64) []int64 {
+	numBlocks := size / ioSize
+	offsets := make([]int64, numBlocks)
+	for i := range offsets {
+		offsets[i] = int64(i) * ioSize
+	}
+	return offsets
+}
+
+// verifyWriteThenRead walks every ioSize block of target, first writing the
+// deterministic pattern to each one, then re-reading every block and
+// comparing it against the regenerated pattern.
+func (e *Engine) verifyWriteThenRead(target Target, ioSize int64, threads int, seed int64, size int64, results *Results, progressCallback func(string)) error {
+	offsets := verifyBlockOffsets(ioSize, size)
+
+	progressCallback(fmt.Sprintf("\nVerify: writing pattern to %d blocks...", len(offsets)))
+	if err := e.verifyWalk(target, offsets, ioSize, threads, func(buf []byte, offset int64) error {
+		verifyPattern(buf, offset, seed)
+		_, err := target.WriteAt(buf, offset)
+		return err
+	}); err != nil {
+		return fmt.Errorf("verify write phase failed: %v", err)
+	}
+
+	progressCallback(fmt.Sprintf("\nVerify: reading back %d blocks...", len(offsets)))
+	var mu sync.Mutex
+	var blocksChecked atomic.Int64
+	if err := e.verifyWalk(target, offsets, ioSize, threads, func(buf []byte, offset int64) error {
+		n, err := target.ReadAt(buf, offset)
+		if err != nil {
+			return err
+		}
+		blocksChecked.Add(1)
+		if ok, expected, actual := verifyBlock(buf[:n], offset, seed); !ok {
+			recordVerifyError(&mu, results, offset, expected, actual)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("verify read phase failed: %v", err)
+	}
+	results.VerifyBlocksChecked = blocksChecked.Load()
+
+	progressCallback(fmt.Sprintf("\nVerify complete: %d blocks checked, %d mismatches", results.VerifyBlocksChecked, len(results.VerifyErrors)))
+	return nil
+}
+
+// verifyWalk distributes offsets across threads worker goroutines, each
+// running op against its own buffer, mirroring PrepDevice's chunked
+// work-queue pattern.
+func (e *Engine) verifyWalk(target Target, offsets []int64, ioSize int64, threads int, op func(buf []byte, offset int64) error) error {
+	tasks := make(chan int64, threads*4)
+	errs := make(chan error, threads)
+	var wg sync.WaitGroup
+
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, ioSize)
+			for offset := range tasks {
+				if err := op(buf, offset); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, offset := range offsets {
+			tasks <- offset
+		}
+		close(tasks)
+	}()
+
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyInline runs threads workers for duration seconds, each alternating
+// a write and an immediate read-back of the same offset, so corruption is
+// caught the moment it happens rather than after a full-device pass.
+func (e *Engine) verifyInline(target Target, ioSize int64, threads int, duration int, seed int64, size int64, results *Results, progressCallback func(string)) error {
+	if duration <= 0 {
+		duration = 10
+	}
+
+	var blocksChecked atomic.Int64
+	var mu sync.Mutex
+	stopChan := make(chan bool)
+	var wg sync.WaitGroup
+
+	numBlocks := size / ioSize
+	if numBlocks <= 0 {
+		numBlocks = 1
+	}
+
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			buf := make([]byte, ioSize)
+			blockIdx := int64(workerID)
+			for {
+				select {
+				case <-stopChan:
+					return
+				default:
+				}
+
+				offset := (blockIdx % numBlocks) * ioSize
+				blockIdx += int64(threads)
+
+				verifyPattern(buf, offset, seed)
+				if _, err := target.WriteAt(buf, offset); err != nil {
+					continue
+				}
+				n, err := target.ReadAt(buf, offset)
+				if err != nil {
+					continue
+				}
+				blocksChecked.Add(1)
+				if ok, expected, actual := verifyBlock(buf[:n], offset, seed); !ok {
+					recordVerifyError(&mu, results, offset, expected, actual)
+				}
+			}
+		}(i)
+	}
+
+	progressCallback(fmt.Sprintf("\nVerify (inline): running for %ds...", duration))
+	select {
+	case <-time.After(time.Duration(duration) * time.Second):
+	case <-e.stopChan:
+		progressCallback("  Verify stopped by user")
+	}
+	close(stopChan)
+	wg.Wait()
+
+	results.VerifyBlocksChecked = blocksChecked.Load()
+	progressCallback(fmt.Sprintf("\nVerify complete: %d blocks checked, %d mismatches", results.VerifyBlocksChecked, len(results.VerifyErrors)))
+	return nil
+}
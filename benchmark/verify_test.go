@@ -0,0 +1,61 @@
+package benchmark
+
+import "testing"
+
+func TestVerifyBlockRoundTrips(t *testing.T) {
+	buf := make([]byte, 512)
+	verifyPattern(buf, 4096, 12345)
+
+	ok, expected, actual := verifyBlock(buf, 4096, 12345)
+	if !ok {
+		t.Fatalf("expected freshly-written pattern to verify, got expected=%x actual=%x", expected, actual)
+	}
+}
+
+func TestVerifyBlockDetectsCorruption(t *testing.T) {
+	buf := make([]byte, 512)
+	verifyPattern(buf, 4096, 12345)
+	buf[64] ^= 0xff
+
+	ok, expected, actual := verifyBlock(buf, 4096, 12345)
+	if ok {
+		t.Fatal("expected a flipped byte to fail verification")
+	}
+	if expected == actual {
+		t.Fatalf("expected and actual checksums should differ on corruption, both were %x", expected)
+	}
+}
+
+func TestVerifyBlockDetectsWrongOffset(t *testing.T) {
+	buf := make([]byte, 512)
+	verifyPattern(buf, 4096, 12345)
+
+	// A block correctly written for one offset must not verify against a
+	// different offset - otherwise a misplaced write (e.g. a block swapped
+	// between two offsets) would go undetected.
+	if ok, _, _ := verifyBlock(buf, 8192, 12345); ok {
+		t.Fatal("expected a pattern written for offset 4096 to fail verification at offset 8192")
+	}
+}
+
+func TestVerifyBlockDetectsWrongSeed(t *testing.T) {
+	buf := make([]byte, 512)
+	verifyPattern(buf, 4096, 12345)
+
+	if ok, _, _ := verifyBlock(buf, 4096, 54321); ok {
+		t.Fatal("expected a pattern written with one seed to fail verification against another")
+	}
+}
+
+func TestVerifyBlockOffsetsCoversWholeSize(t *testing.T) {
+	offsets := verifyBlockOffsets(512, 2048)
+	want := []int64{0, 512, 1024, 1536}
+	if len(offsets) != len(want) {
+		t.Fatalf("expected %d offsets, got %d: %v", len(want), len(offsets), offsets)
+	}
+	for i, off := range want {
+		if offsets[i] != off {
+			t.Errorf("offset %d: expected %d, got %d", i, off, offsets[i])
+		}
+	}
+}
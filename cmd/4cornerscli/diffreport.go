@@ -0,0 +1,35 @@
+package main
+
+import (
+	"4corners/benchmark"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runDiffReport implements `4corners diff-report <baseline.json> <compare.json>`,
+// printing the headline metric deltas between two runs saved by the history
+// library (SaveRun) or any json-format report (ExportReports).
+func runDiffReport(args []string) {
+	fs := flag.NewFlagSet("diff-report", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff-report <baseline-run.json> <compare-run.json>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	baseline, err := benchmark.LoadRun(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+
+	compare, err := benchmark.LoadRun(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(1), err)
+		os.Exit(1)
+	}
+
+	fmt.Print(benchmark.DiffReport(baseline, compare))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"4corners/benchmark"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runJobfile implements `4corners jobfile -device <path> <job-file>`, loading
+// a third-party fio-style job file via benchmark.LoadJobSpecs and running it
+// with benchmark.Engine.RunJobs, the entry point for JobSpec/JobResult that
+// the rest of the CLI otherwise has no way to reach.
+func runJobfile(args []string) {
+	fs := flag.NewFlagSet("jobfile", flag.ExitOnError)
+	devicePath := fs.String("device", "", "Device or file path to run the job file against (required)")
+	fs.Parse(args)
+
+	if *devicePath == "" || fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s jobfile -device <path> <job-file.fio>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	jobs, err := benchmark.LoadJobSpecs(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+
+	engine := benchmark.NewEngine()
+	results, err := engine.RunJobs(*devicePath, jobs, func(msg string) {
+		fmt.Println(msg)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println("Job results:")
+	for _, r := range results {
+		fmt.Printf("  %-20s %8.2f/%8.2f MB/s R/W | %8.0f/%8.0f IOPS R/W | p50 %6.2f/%6.2f ms | p99 %6.2f/%6.2f ms\n",
+			r.Name, r.ReadThroughputMBps, r.WriteThroughputMBps, r.ReadIOPS, r.WriteIOPS,
+			r.ReadLatency.P50Ms, r.WriteLatency.P50Ms, r.ReadLatency.P99Ms, r.WriteLatency.P99Ms)
+	}
+}
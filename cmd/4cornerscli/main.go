@@ -3,6 +3,8 @@ package main
 import (
 	"4corners/benchmark"
 	"4corners/device"
+	"4corners/device/smart"
+	"4corners/sysstat"
 	"flag"
 	"fmt"
 	"os"
@@ -29,9 +31,30 @@ type config struct {
 	createFile         bool
 	fileSize           int64
 	runTests           string
+	smart              bool
+	ioMaxRBps          int64
+	ioMaxWBps          int64
+	ioMaxRIOPS         int64
+	ioMaxWIOPS         int64
+	reportFormats      string
+	reportTags         string
+	jsonEvents         bool
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff-report" {
+		runDiffReport(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "jobfile" {
+		runJobfile(os.Args[2:])
+		return
+	}
+
 	cfg := parseFlags()
 	
 	if cfg.devicePath == "" {
@@ -59,7 +82,19 @@ func main() {
 	
 	// Create benchmark engine
 	engine := benchmark.NewEngine()
-	
+
+	// -events streams the same structured progress events the GUI consumes
+	// as newline-delimited JSON on stdout, for headless monitoring.
+	if cfg.jsonEvents {
+		engine.OnEvent(func(ev benchmark.Event) {
+			data, err := ev.MarshalNDJSON()
+			if err != nil {
+				return
+			}
+			os.Stdout.Write(data)
+		})
+	}
+
 	// Prep device if requested
 	if cfg.prepDevice {
 		fmt.Printf("Preparing device: %s\n", cfg.devicePath)
@@ -103,11 +138,37 @@ func main() {
 		WriteTPQueueDepth:   cfg.writeTPQueueDepth,
 		ReadIOPSQueueDepth:  cfg.readIOPSQueueDepth,
 		WriteIOPSQueueDepth: cfg.writeIOPSQueueDepth,
+		SMART:               cfg.smart,
+		IOMaxRBps:           cfg.ioMaxRBps,
+		IOMaxWBps:           cfg.ioMaxWBps,
+		IOMaxRIOPS:          cfg.ioMaxRIOPS,
+		IOMaxWIOPS:          cfg.ioMaxWIOPS,
 	}
-	
+
+	teardownIsolation, err := engine.ApplyIsolation(config)
+	if err != nil {
+		fmt.Printf("Error applying io.max isolation: %v\n", err)
+		os.Exit(1)
+	}
+	if teardownIsolation != nil {
+		defer teardownIsolation()
+	}
+
 	results := &benchmark.Results{
-		TestDate: time.Now(),
-		Config:   config,
+		TestDate:  time.Now(),
+		Config:    config,
+		HostStats: make(map[string][]sysstat.Sample),
+		SMART:     make(map[string]benchmark.SMARTStats),
+	}
+
+	// snapshotSMART reads the device's SMART/health attributes, or the zero
+	// Info if -smart wasn't passed or the device didn't answer.
+	snapshotSMART := func() smart.Info {
+		if !cfg.smart {
+			return smart.Info{}
+		}
+		info, _ := smart.Read(cfg.devicePath)
+		return info
 	}
 	
 	// Progress callback
@@ -118,16 +179,30 @@ func main() {
 	// Run Read Throughput test
 	if runReadTP {
 		fmt.Println("Running Read Throughput Test...")
-		throughput, iops, latency, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.readTPBlockSize*1024), cfg.readTPThreads, cfg.duration, cfg.readTPQueueDepth, false, progressCallback)
+		smartBefore := snapshotSMART()
+		throughput, iops, latency, stats, hostStats, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.readTPBlockSize*1024), cfg.readTPThreads, cfg.duration, cfg.readTPQueueDepth, false, "Read Throughput", progressCallback)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 		} else {
+			results.SMART["Read Throughput"] = benchmark.SMARTStats{Before: smartBefore, After: snapshotSMART()}
 			results.ReadThroughputMBps = throughput
 			results.ReadThroughputIOPS = iops
 			results.ReadTPLatencyMs = latency
+			results.ReadTPLatencyMinMs = stats.MinMs
+			results.ReadTPLatencyP50Ms = stats.P50Ms
+			results.ReadTPLatencyP95Ms = stats.P95Ms
+			results.ReadTPLatencyP99Ms = stats.P99Ms
+			results.ReadTPLatencyP999Ms = stats.P999Ms
+			results.ReadTPLatencyP9999Ms = stats.P9999Ms
+			results.ReadTPLatencyMaxMs = stats.MaxMs
+			results.ReadTPLatencyStdevMs = stats.StdevMs
+			results.ReadTPLatencyHistogram = stats.Histogram
+			results.ReadTPLatencyBuckets = stats.Buckets
+			results.ReadTPLatencySparkline = stats.Sparkline
 			results.ReadTPThreads = cfg.readTPThreads
 			results.ReadTPDuration = cfg.duration
 			results.ReadTPQueueDepth = cfg.readTPQueueDepth
+			results.HostStats["Read Throughput"] = hostStats
 		}
 		fmt.Println()
 	}
@@ -135,16 +210,30 @@ func main() {
 	// Run Write Throughput test
 	if runWriteTP {
 		fmt.Println("Running Write Throughput Test...")
-		throughput, iops, latency, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.writeTPBlockSize*1024), cfg.writeTPThreads, cfg.duration, cfg.writeTPQueueDepth, true, progressCallback)
+		smartBefore := snapshotSMART()
+		throughput, iops, latency, stats, hostStats, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.writeTPBlockSize*1024), cfg.writeTPThreads, cfg.duration, cfg.writeTPQueueDepth, true, "Write Throughput", progressCallback)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 		} else {
+			results.SMART["Write Throughput"] = benchmark.SMARTStats{Before: smartBefore, After: snapshotSMART()}
 			results.WriteThroughputMBps = throughput
 			results.WriteThroughputIOPS = iops
 			results.WriteTPLatencyMs = latency
+			results.WriteTPLatencyMinMs = stats.MinMs
+			results.WriteTPLatencyP50Ms = stats.P50Ms
+			results.WriteTPLatencyP95Ms = stats.P95Ms
+			results.WriteTPLatencyP99Ms = stats.P99Ms
+			results.WriteTPLatencyP999Ms = stats.P999Ms
+			results.WriteTPLatencyP9999Ms = stats.P9999Ms
+			results.WriteTPLatencyMaxMs = stats.MaxMs
+			results.WriteTPLatencyStdevMs = stats.StdevMs
+			results.WriteTPLatencyHistogram = stats.Histogram
+			results.WriteTPLatencyBuckets = stats.Buckets
+			results.WriteTPLatencySparkline = stats.Sparkline
 			results.WriteTPThreads = cfg.writeTPThreads
 			results.WriteTPDuration = cfg.duration
 			results.WriteTPQueueDepth = cfg.writeTPQueueDepth
+			results.HostStats["Write Throughput"] = hostStats
 		}
 		fmt.Println()
 	}
@@ -152,16 +241,30 @@ func main() {
 	// Run Read IOPS test
 	if runReadIOPS {
 		fmt.Println("Running Read IOPS Test...")
-		throughput, iops, latency, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.readIOPSBlockSize*1024), cfg.readIOPSThreads, cfg.duration, cfg.readIOPSQueueDepth, false, progressCallback)
+		smartBefore := snapshotSMART()
+		throughput, iops, latency, stats, hostStats, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.readIOPSBlockSize*1024), cfg.readIOPSThreads, cfg.duration, cfg.readIOPSQueueDepth, false, "Read IOPS", progressCallback)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 		} else {
+			results.SMART["Read IOPS"] = benchmark.SMARTStats{Before: smartBefore, After: snapshotSMART()}
 			results.ReadIOPSThroughputMBps = throughput
 			results.ReadIOPS = iops
 			results.ReadIOPSLatencyMs = latency
+			results.ReadIOPSLatencyMinMs = stats.MinMs
+			results.ReadIOPSLatencyP50Ms = stats.P50Ms
+			results.ReadIOPSLatencyP95Ms = stats.P95Ms
+			results.ReadIOPSLatencyP99Ms = stats.P99Ms
+			results.ReadIOPSLatencyP999Ms = stats.P999Ms
+			results.ReadIOPSLatencyP9999Ms = stats.P9999Ms
+			results.ReadIOPSLatencyMaxMs = stats.MaxMs
+			results.ReadIOPSLatencyStdevMs = stats.StdevMs
+			results.ReadIOPSLatencyHistogram = stats.Histogram
+			results.ReadIOPSLatencyBuckets = stats.Buckets
+			results.ReadIOPSLatencySparkline = stats.Sparkline
 			results.ReadIOPSThreads = cfg.readIOPSThreads
 			results.ReadIOPSDuration = cfg.duration
 			results.ReadIOPSQueueDepth = cfg.readIOPSQueueDepth
+			results.HostStats["Read IOPS"] = hostStats
 		}
 		fmt.Println()
 	}
@@ -169,16 +272,30 @@ func main() {
 	// Run Write IOPS test
 	if runWriteIOPS {
 		fmt.Println("Running Write IOPS Test...")
-		throughput, iops, latency, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.writeIOPSBlockSize*1024), cfg.writeIOPSThreads, cfg.duration, cfg.writeIOPSQueueDepth, true, progressCallback)
+		smartBefore := snapshotSMART()
+		throughput, iops, latency, stats, hostStats, err := engine.RunSingleTest(cfg.devicePath, int64(cfg.writeIOPSBlockSize*1024), cfg.writeIOPSThreads, cfg.duration, cfg.writeIOPSQueueDepth, true, "Write IOPS", progressCallback)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 		} else {
+			results.SMART["Write IOPS"] = benchmark.SMARTStats{Before: smartBefore, After: snapshotSMART()}
 			results.WriteIOPSThroughputMBps = throughput
 			results.WriteIOPS = iops
 			results.WriteIOPSLatencyMs = latency
+			results.WriteIOPSLatencyMinMs = stats.MinMs
+			results.WriteIOPSLatencyP50Ms = stats.P50Ms
+			results.WriteIOPSLatencyP95Ms = stats.P95Ms
+			results.WriteIOPSLatencyP99Ms = stats.P99Ms
+			results.WriteIOPSLatencyP999Ms = stats.P999Ms
+			results.WriteIOPSLatencyP9999Ms = stats.P9999Ms
+			results.WriteIOPSLatencyMaxMs = stats.MaxMs
+			results.WriteIOPSLatencyStdevMs = stats.StdevMs
+			results.WriteIOPSLatencyHistogram = stats.Histogram
+			results.WriteIOPSLatencyBuckets = stats.Buckets
+			results.WriteIOPSLatencySparkline = stats.Sparkline
 			results.WriteIOPSThreads = cfg.writeIOPSThreads
 			results.WriteIOPSDuration = cfg.duration
 			results.WriteIOPSQueueDepth = cfg.writeIOPSQueueDepth
+			results.HostStats["Write IOPS"] = hostStats
 		}
 		fmt.Println()
 	}
@@ -192,7 +309,8 @@ func main() {
 	fmt.Println(report)
 	
 	// Save report files
-	err := results.SaveReport(".")
+	formats := strings.Split(cfg.reportFormats, ",")
+	err = results.ExportReports(".", formats, benchmark.ParseReportTags(cfg.reportTags))
 	if err != nil {
 		fmt.Printf("Warning: failed to save reports: %v\n", err)
 	} else {
@@ -226,10 +344,25 @@ func parseFlags() config {
 	flag.Int64Var(&cfg.fileSize, "file-size", 10, "File device size in GB (if creating)")
 	
 	flag.StringVar(&cfg.runTests, "tests", "all", "Tests to run: all, read-tp, write-tp, read-iops, write-iops (comma-separated)")
-	
+
+	flag.BoolVar(&cfg.smart, "smart", false, "Snapshot device SMART/health attributes before and after each test")
+
+	flag.Int64Var(&cfg.ioMaxRBps, "io-max-rbps", 0, "Cap device read bytes/sec via a Linux cgroup v2 io.max scope (0 = unrestricted; requires CAP_SYS_ADMIN)")
+	flag.Int64Var(&cfg.ioMaxWBps, "io-max-wbps", 0, "Cap device write bytes/sec via a Linux cgroup v2 io.max scope (0 = unrestricted)")
+	flag.Int64Var(&cfg.ioMaxRIOPS, "io-max-riops", 0, "Cap device read IOPS via a Linux cgroup v2 io.max scope (0 = unrestricted)")
+	flag.Int64Var(&cfg.ioMaxWIOPS, "io-max-wiops", 0, "Cap device write IOPS via a Linux cgroup v2 io.max scope (0 = unrestricted)")
+
+	flag.StringVar(&cfg.reportFormats, "report-formats", "json,txt", "Report formats to write: json, txt, csv, prom, influx (comma-separated)")
+	flag.StringVar(&cfg.reportTags, "report-tags", "", "Tags to attach to prom/influx output, e.g. \"host=web01,model=980PRO\" (comma-separated key=value pairs)")
+
+	flag.BoolVar(&cfg.jsonEvents, "events", false, "Stream structured progress events (samples, phase boundaries, errors) as newline-delimited JSON on stdout")
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "4Corners Disk Benchmark - CLI\n\n")
-		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s serve [-listen :7070]   (headless HTTP/WebSocket control mode)\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s diff-report <baseline-run.json> <compare-run.json>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s jobfile -device <path> <job-file.fio>   (run a third-party fio job file)\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
@@ -241,6 +374,14 @@ func parseFlags() config {
 		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -tests read-tp,write-tp\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  # Custom thread counts and duration\n")
 		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -duration 120 -read-tp-threads 64 -write-tp-threads 32\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Track temperature rise and wear alongside the benchmark\n")
+		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -smart\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Verify a 500 MB/s read QoS cap actually holds\n")
+		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -io-max-rbps 524288000\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Export Prometheus textfile and InfluxDB line protocol, tagged by host\n")
+		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -report-formats json,txt,prom,influx -report-tags host=web01\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Pipe structured progress events to a monitoring sidecar\n")
+		fmt.Fprintf(os.Stderr, "  %s -device /dev/sdb -events | jq .\n\n", os.Args[0])
 	}
 	
 	flag.Parse()
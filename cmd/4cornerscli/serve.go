@@ -0,0 +1,514 @@
+package main
+
+import (
+	"4corners/benchmark"
+	"4corners/config"
+	"4corners/gui"
+	"4corners/logging"
+	"4corners/presets"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// runServe implements `4corners serve`: a headless HTTP/WebSocket front end
+// for the same Controller the webview GUI binds to, so a storage node or
+// hypervisor with no display can still drive and watch a benchmark from any
+// browser on the network.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", ":7070", "Address to listen on")
+	token := fs.String("token", "", "Shared-secret required on every /api and /ws request, as '?token=' or 'Authorization: Bearer '; a random one is generated and printed if omitted")
+	fs.Parse(args)
+
+	if *token == "" {
+		generated, err := randomToken()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+			os.Exit(1)
+		}
+		*token = generated
+	}
+	fmt.Printf("4Corners auth token (pass as '?token=...' or 'Authorization: Bearer ...'): %s\n", *token)
+
+	srv := newServeServer(*token)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/devices", srv.auth(srv.handleDevices))
+	mux.HandleFunc("/api/select-devices", srv.auth(srv.handleSelectDevices))
+	mux.HandleFunc("/api/prep", srv.auth(srv.handlePrep))
+	mux.HandleFunc("/api/run", srv.auth(srv.handleRun))
+	mux.HandleFunc("/api/stop", srv.auth(srv.handleStop))
+	mux.HandleFunc("/api/save-location", srv.auth(srv.handleSaveLocation))
+	mux.HandleFunc("/api/runs", srv.auth(srv.handleRuns))
+	mux.HandleFunc("/api/runs/overlay", srv.auth(srv.handleRunOverlay))
+	mux.HandleFunc("/api/config-link/encode", srv.auth(srv.handleConfigLinkEncode))
+	mux.HandleFunc("/api/config-link/decode", srv.auth(srv.handleConfigLinkDecode))
+	mux.HandleFunc("/api/presets", srv.auth(srv.handlePresetsList))
+	mux.HandleFunc("/api/presets/", srv.auth(srv.handlePresetItem))
+	mux.HandleFunc("/ws", srv.auth(srv.handleWebSocket))
+	mux.HandleFunc("/ws/stream", srv.auth(srv.handleStreamWebSocket))
+	mux.HandleFunc("/", srv.handleAsset)
+
+	fmt.Printf("4Corners headless server listening on %s\n", *listen)
+	if err := http.ListenAndServe(*listen, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// randomToken generates a 32-byte, hex-encoded shared secret for -token.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate auth token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// serveServer wraps a gui.Controller with the HTTP/WebSocket handlers that
+// drive it, plus the set of WebSocket clients currently watching a run.
+type serveServer struct {
+	ctrl  *gui.Controller
+	token string
+
+	mu            sync.Mutex
+	clients       map[*websocket.Conn]bool
+	streamClients map[*websocket.Conn]bool
+}
+
+func newServeServer(token string) *serveServer {
+	s := &serveServer{
+		ctrl:          gui.NewController(),
+		token:         token,
+		clients:       make(map[*websocket.Conn]bool),
+		streamClients: make(map[*websocket.Conn]bool),
+	}
+	s.ctrl.Logger().AddSink(logging.CallbackSink(s.broadcastLogEntry))
+	return s
+}
+
+// auth wraps next so it only runs for requests carrying s.token, either as
+// the '?token=' query parameter or an 'Authorization: Bearer ' header -
+// every /api and /ws route needs this, since handleRun lets a caller launch
+// a benchmark (and handlePrep zero-fill a device) against any Device path
+// the server process can open.
+func (s *serveServer) auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *serveServer) checkToken(r *http.Request) bool {
+	got := r.URL.Query().Get("token")
+	if got == "" {
+		if auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			got = auth
+		}
+	}
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+// broadcastLogEntry fans a structured log Entry out to every /ws client,
+// wrapped in a {"type":"log_entry",...} envelope so it can share the socket
+// with broadcastEvent's raw benchmark.Event frames without either side
+// needing to guess at the other's shape.
+func (s *serveServer) broadcastLogEntry(e logging.Entry) {
+	data, err := json.Marshal(struct {
+		Type  string        `json:"type"`
+		Entry logging.Entry `json:"entry"`
+	}{Type: "log_entry", Entry: e})
+	if err != nil {
+		return
+	}
+	s.broadcast(websocket.TextMessage, data)
+}
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: sameOriginOrNoOrigin,
+}
+
+// sameOriginOrNoOrigin rejects a WebSocket upgrade whose Origin header names
+// a different host than the request itself - the default gorilla/websocket
+// behavior, disabled here before, let any page on the web open a socket to
+// this server and ride the operator's browser session. Non-browser clients
+// (curl, a CLI websocket tool, the token-bearing automation this server is
+// meant for) don't send an Origin header at all, so those are let through.
+func sameOriginOrNoOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+func (s *serveServer) handleAsset(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Path[1:]
+	if name == "" {
+		name = "index.html"
+	}
+	data, ok := gui.Assets[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", assetContentType(name))
+	w.Header().Set("ETag", `"`+gui.AssetChecksums[name]+`"`)
+	w.Write(data)
+}
+
+// assetContentType mirrors gui.contentType (unexported there, since it's only
+// needed by webview.go's loopback server): it maps an asset's extension to
+// the Content-Type the browser needs to treat it as CSS/JS rather than
+// sniffing it as plain text.
+func assetContentType(name string) string {
+	switch filepath.Ext(name) {
+	case ".css":
+		return "text/css; charset=utf-8"
+	case ".js":
+		return "application/javascript; charset=utf-8"
+	default:
+		return "text/html; charset=utf-8"
+	}
+}
+
+func readAll(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
+
+func (s *serveServer) handleDevices(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, s.ctrl.ListDevices())
+}
+
+func (s *serveServer) handleSelectDevices(w http.ResponseWriter, r *http.Request) {
+	body, err := readAll(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	names, err := s.ctrl.SelectDevices(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(names)
+}
+
+func (s *serveServer) handlePrep(w http.ResponseWriter, r *http.Request) {
+	err := s.ctrl.PrepDevice(func(msg string) {
+		s.broadcastText(msg)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serveServer) handleRun(w http.ResponseWriter, r *http.Request) {
+	var benchConfig benchmark.Config
+	if err := json.NewDecoder(r.Body).Decode(&benchConfig); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	go func() {
+		_, err := s.ctrl.RunBenchmark(benchConfig, func(ev benchmark.Event) {
+			s.broadcastEvent(ev)
+			s.broadcastStreamFrame(ev)
+		}, s.broadcastText)
+		if err != nil {
+			s.broadcastText(fmt.Sprintf("Benchmark failed: %v", err))
+		}
+	}()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *serveServer) handleStop(w http.ResponseWriter, r *http.Request) {
+	s.ctrl.StopBenchmark()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serveServer) handleSaveLocation(w http.ResponseWriter, r *http.Request) {
+	body, err := readAll(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.ctrl.SetSaveLocation(string(body))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serveServer) handleRuns(w http.ResponseWriter, r *http.Request) {
+	data, err := s.ctrl.ListRuns()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, data)
+}
+
+func (s *serveServer) handleRunOverlay(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		http.Error(w, "missing path query parameter", http.StatusBadRequest)
+		return
+	}
+
+	data, err := s.ctrl.LoadRunOverlay(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, data)
+}
+
+// handleConfigLinkEncode accepts a Test Configuration form payload as JSON
+// and returns its shareable config-link payload, the REST equivalent of
+// webview.go's encodeConfigLink binding.
+func (s *serveServer) handleConfigLinkEncode(w http.ResponseWriter, r *http.Request) {
+	var cfg config.Config
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	link, err := config.EncodeFragment(cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"link": link})
+}
+
+// handleConfigLinkDecode reverses handleConfigLinkEncode: given a
+// `?payload=` config-link payload, it returns the Test Configuration form
+// values as JSON.
+func (s *serveServer) handleConfigLinkDecode(w http.ResponseWriter, r *http.Request) {
+	payload := r.URL.Query().Get("payload")
+	if payload == "" {
+		http.Error(w, "missing payload query parameter", http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.DecodeFragment(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cfg)
+}
+
+// handlePresetsList implements GET /api/presets, returning every saved
+// preset as JSON for the preset dropdown.
+func (s *serveServer) handlePresetsList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := s.ctrl.ListPresets()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, data)
+}
+
+// handlePresetItem implements PUT/DELETE /api/presets/{name}: PUT saves (or
+// overwrites) the named preset from the request body, DELETE removes it.
+func (s *serveServer) handlePresetItem(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/presets/")
+	if name == "" {
+		http.Error(w, "missing preset name", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var p presets.Preset
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.Name = name
+
+		if err := s.ctrl.SavePreset(p); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if err := s.ctrl.DeletePreset(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleWebSocket upgrades the connection and registers it to receive every
+// benchmark.Event and progress line broadcast by a run in progress, whether
+// that run was started from this connection or another client entirely.
+func (s *serveServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.clients[conn] = true
+	s.mu.Unlock()
+
+	// The client never sends anything meaningful; reading until it errors
+	// is just how we notice it disconnected.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.clients, conn)
+	s.mu.Unlock()
+	conn.Close()
+}
+
+func (s *serveServer) broadcastEvent(ev benchmark.Event) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	s.broadcast(websocket.TextMessage, data)
+}
+
+// handleStreamWebSocket upgrades the connection to /ws/stream and replays
+// the active run's TimeSeries history before registering the client to
+// receive live per-sample frames, so a browser opening this socket mid-run
+// sees the sparkline panel's recent history instead of a blank chart until
+// the next sample arrives.
+func (s *serveServer) handleStreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte(s.ctrl.TimeSeriesSnapshot()))
+
+	s.mu.Lock()
+	s.streamClients[conn] = true
+	s.mu.Unlock()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.streamClients, conn)
+	s.mu.Unlock()
+	conn.Close()
+}
+
+// streamFrame is one /ws/stream sample frame: the per-second metrics a
+// phase's sparkline draws, tagged with the run that produced it so a client
+// watching a different (or stopped) run can discard it instead of grafting
+// it onto the wrong chart.
+type streamFrame struct {
+	Type  string  `json:"type"`
+	RunID int64   `json:"runId"`
+	Phase string  `json:"phase"`
+	T     int64   `json:"t"`
+	MBps  float64 `json:"mbps"`
+	IOPS  float64 `json:"iops"`
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+	P999  float64 `json:"p999"`
+}
+
+// broadcastStreamFrame fans out one EventSample to every /ws/stream client
+// as a streamFrame; other event types carry no per-second metrics and are
+// ignored here (they still reach broadcastEvent/the /ws feed).
+func (s *serveServer) broadcastStreamFrame(ev benchmark.Event) {
+	if ev.Type != benchmark.EventSample {
+		return
+	}
+
+	data, err := json.Marshal(streamFrame{
+		Type:  "sample",
+		RunID: s.ctrl.CurrentRunID(),
+		Phase: ev.Phase,
+		T:     ev.Timestamp.UnixMilli(),
+		MBps:  ev.MBps,
+		IOPS:  ev.IOPS,
+		P50:   ev.LatP50Ms,
+		P95:   ev.LatP95Ms,
+		P99:   ev.LatP99Ms,
+		P999:  ev.LatP999Ms,
+	})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.streamClients {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			conn.Close()
+			delete(s.streamClients, conn)
+		}
+	}
+}
+
+func (s *serveServer) broadcastText(msg string) {
+	data, err := json.Marshal(map[string]string{"type": "log", "message": msg})
+	if err != nil {
+		return
+	}
+	s.broadcast(websocket.TextMessage, data)
+}
+
+func (s *serveServer) broadcast(messageType int, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.clients {
+		if err := conn.WriteMessage(messageType, data); err != nil {
+			conn.Close()
+			delete(s.clients, conn)
+		}
+	}
+}
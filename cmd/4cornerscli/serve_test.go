@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckTokenAcceptsQueryParam(t *testing.T) {
+	s := newServeServer("secret")
+	r := httptest.NewRequest(http.MethodGet, "/api/devices?token=secret", nil)
+	if !s.checkToken(r) {
+		t.Fatal("expected the correct ?token= query param to be accepted")
+	}
+}
+
+func TestCheckTokenAcceptsBearerHeader(t *testing.T) {
+	s := newServeServer("secret")
+	r := httptest.NewRequest(http.MethodGet, "/api/devices", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	if !s.checkToken(r) {
+		t.Fatal("expected a correct Authorization: Bearer header to be accepted")
+	}
+}
+
+func TestCheckTokenRejectsWrongOrMissingToken(t *testing.T) {
+	s := newServeServer("secret")
+
+	noToken := httptest.NewRequest(http.MethodGet, "/api/devices", nil)
+	if s.checkToken(noToken) {
+		t.Error("expected a request with no token to be rejected")
+	}
+
+	wrongToken := httptest.NewRequest(http.MethodGet, "/api/devices?token=wrong", nil)
+	if s.checkToken(wrongToken) {
+		t.Error("expected an incorrect token to be rejected")
+	}
+}
+
+func TestSameOriginOrNoOriginAllowsNoOriginHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Host = "localhost:7070"
+	if !sameOriginOrNoOrigin(r) {
+		t.Fatal("expected a request with no Origin header (e.g. a non-browser client) to be allowed")
+	}
+}
+
+func TestSameOriginOrNoOriginAllowsMatchingOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Host = "localhost:7070"
+	r.Header.Set("Origin", "http://localhost:7070")
+	if !sameOriginOrNoOrigin(r) {
+		t.Fatal("expected an Origin matching the request Host to be allowed")
+	}
+}
+
+func TestSameOriginOrNoOriginRejectsCrossSiteOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Host = "localhost:7070"
+	r.Header.Set("Origin", "http://evil.example.com")
+	if sameOriginOrNoOrigin(r) {
+		t.Fatal("expected a cross-site Origin to be rejected")
+	}
+}
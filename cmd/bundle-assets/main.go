@@ -0,0 +1,99 @@
+// Command bundle-assets regenerates gui/assets_generated.go from the files
+// under gui/assets. Run it (or `go generate ./gui`) whenever index.html,
+// app.css, or one of the JS files changes.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	assetsDir  = "gui/assets"
+	outputFile = "gui/assets_generated.go"
+)
+
+func main() {
+	entries, err := os.ReadDir(assetsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bundle-assets: %v\n", err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	contents := make(map[string][]byte, len(names))
+	checksums := make(map[string]string, len(names))
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(assetsDir, name))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bundle-assets: %v\n", err)
+			os.Exit(1)
+		}
+		data := minify(name, raw)
+		sum := sha256.Sum256(data)
+		contents[name] = data
+		checksums[name] = hex.EncodeToString(sum[:])
+	}
+
+	if err := write(names, contents, checksums); err != nil {
+		fmt.Fprintf(os.Stderr, "bundle-assets: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// minify strips trailing whitespace and blank lines from CSS/JS assets. HTML
+// is left untouched: webview renders it as-is, and the markup is small
+// enough that minifying it wouldn't meaningfully shrink the binary.
+func minify(name string, raw []byte) []byte {
+	switch filepath.Ext(name) {
+	case ".css", ".js":
+	default:
+		return raw
+	}
+
+	lines := strings.Split(string(raw), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, " \t\r")
+		if trimmed == "" {
+			continue
+		}
+		kept = append(kept, trimmed)
+	}
+	return []byte(strings.Join(kept, "\n") + "\n")
+}
+
+func write(names []string, contents map[string][]byte, checksums map[string]string) error {
+	var b strings.Builder
+	b.WriteString("// Code generated by cmd/bundle-assets from gui/assets; DO NOT EDIT.\n\n")
+	b.WriteString("package gui\n\n")
+	b.WriteString("// Assets maps each gui/assets file name to its bundled (minified) contents.\n")
+	b.WriteString("var Assets = map[string][]byte{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%q: []byte(%q),\n", name, contents[name])
+	}
+	b.WriteString("}\n\n")
+	b.WriteString("// AssetChecksums maps each gui/assets file name to the SHA-256 hex digest of\n")
+	b.WriteString("// its bundled contents, so servers can cache-bust by checksum instead of by\n")
+	b.WriteString("// file modification time.\n")
+	b.WriteString("var AssetChecksums = map[string]string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%q: %q,\n", name, checksums[name])
+	}
+	b.WriteString("}\n")
+
+	return os.WriteFile(outputFile, []byte(b.String()), 0644)
+}
@@ -0,0 +1,125 @@
+// Package config implements a compact, versioned, URL-shareable encoding of
+// the benchmark settings a user builds in the GUI's Test Configuration
+// section (IO size, thread count, queue depth, and duration for each of the
+// four phases), so an exact setup can be circulated as a link instead of
+// re-typed by hand or attached as a separate file.
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// schemaVersion is bumped whenever Config's fields change in a way that
+// breaks decoding an older link. It's written as a raw byte ahead of the
+// JSON payload so Unmarshal can reject an incompatible link before it ever
+// has to parse JSON with the wrong shape.
+const schemaVersion = 1
+
+// Config mirrors the config object the GUI's runBench() builds from the
+// Test Configuration form.
+type Config struct {
+	ReadTPIOSize        float64 `json:"readTPIOSize"`
+	ReadTPThreads       float64 `json:"readTPThreads"`
+	ReadTPQueueDepth    float64 `json:"readTPQueueDepth"`
+	ReadTPDuration      float64 `json:"readTPDuration"`
+	WriteTPIOSize       float64 `json:"writeTPIOSize"`
+	WriteTPThreads      float64 `json:"writeTPThreads"`
+	WriteTPQueueDepth   float64 `json:"writeTPQueueDepth"`
+	WriteTPDuration     float64 `json:"writeTPDuration"`
+	ReadIOPSIOSize      float64 `json:"readIOPSIOSize"`
+	ReadIOPSThreads     float64 `json:"readIOPSThreads"`
+	ReadIOPSQueueDepth  float64 `json:"readIOPSQueueDepth"`
+	ReadIOPSDuration    float64 `json:"readIOPSDuration"`
+	WriteIOPSIOSize     float64 `json:"writeIOPSIOSize"`
+	WriteIOPSThreads    float64 `json:"writeIOPSThreads"`
+	WriteIOPSQueueDepth float64 `json:"writeIOPSQueueDepth"`
+	WriteIOPSDuration   float64 `json:"writeIOPSDuration"`
+}
+
+// Marshal encodes cfg as a schema-version byte followed by its JSON
+// representation.
+func Marshal(cfg Config) ([]byte, error) {
+	payload, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{schemaVersion}, payload...), nil
+}
+
+// Unmarshal reverses Marshal. It fails cleanly on a version byte it doesn't
+// recognize rather than guessing at a partial upgrade.
+func Unmarshal(data []byte) (Config, error) {
+	var cfg Config
+	if len(data) == 0 {
+		return cfg, fmt.Errorf("empty config data")
+	}
+	if data[0] != schemaVersion {
+		return cfg, fmt.Errorf("unsupported config link version %d (this build understands %d)", data[0], schemaVersion)
+	}
+	if err := json.Unmarshal(data[1:], &cfg); err != nil {
+		return cfg, fmt.Errorf("decode config: %w", err)
+	}
+	return cfg, nil
+}
+
+// EncodeFragment gzip-compresses and base64url-encodes cfg for use as a
+// `#cfg=...` URL fragment (or `?cfg=...` query parameter, for contexts that
+// strip everything after a '#').
+func EncodeFragment(cfg Config) (string, error) {
+	data, err := Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeFragment reverses EncodeFragment.
+func DecodeFragment(s string) (Config, error) {
+	var cfg Config
+
+	compressed, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cfg, fmt.Errorf("decode base64: %w", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return cfg, fmt.Errorf("decompress: %w", err)
+	}
+	defer gz.Close()
+
+	// Cap decompressed size: this decodes caller-supplied input (a pasted
+	// link, or /api/config-link/decode), and a small crafted gzip payload can expand
+	// to gigabytes otherwise. Read one byte past the limit so an
+	// oversized fragment gets a clear error instead of a truncated,
+	// confusing Unmarshal failure.
+	data, err := io.ReadAll(io.LimitReader(gz, maxFragmentDecodedSize+1))
+	if err != nil {
+		return cfg, fmt.Errorf("decompress: %w", err)
+	}
+	if len(data) > maxFragmentDecodedSize {
+		return cfg, fmt.Errorf("decompress: fragment exceeds %d byte limit", maxFragmentDecodedSize)
+	}
+
+	return Unmarshal(data)
+}
+
+// maxFragmentDecodedSize bounds how much DecodeFragment will inflate a
+// single fragment to - far more than any real Config needs, but small
+// enough to keep a malicious fragment from exhausting server memory.
+const maxFragmentDecodedSize = 1 << 20
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeFragmentRoundTrip(t *testing.T) {
+	cfg := Config{ReadTPIOSize: 131072, ReadTPThreads: 30, WriteTPDuration: 60}
+
+	frag, err := EncodeFragment(cfg)
+	if err != nil {
+		t.Fatalf("EncodeFragment: %v", err)
+	}
+
+	got, err := DecodeFragment(frag)
+	if err != nil {
+		t.Fatalf("DecodeFragment: %v", err)
+	}
+	if got != cfg {
+		t.Errorf("expected round-tripped config %+v, got %+v", cfg, got)
+	}
+}
+
+func TestUnmarshalRejectsUnknownVersion(t *testing.T) {
+	data := append([]byte{schemaVersion + 1}, []byte("{}")...)
+	if _, err := Unmarshal(data); err == nil {
+		t.Fatal("expected an unrecognized schema version byte to be rejected")
+	}
+}
+
+func TestDecodeFragmentRejectsInvalidBase64(t *testing.T) {
+	if _, err := DecodeFragment("not valid base64url!!"); err == nil {
+		t.Fatal("expected invalid base64 to be rejected")
+	}
+}
+
+// TestDecodeFragmentRejectsOversizedPayload guards the gzip-bomb fix: a
+// fragment that would inflate past maxFragmentDecodedSize must be rejected
+// with a clear error instead of DecodeFragment reading it all into memory.
+func TestDecodeFragmentRejectsOversizedPayload(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(make([]byte, maxFragmentDecodedSize+1)); err != nil {
+		t.Fatalf("gzip.Write: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip.Close: %v", err)
+	}
+
+	frag := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+
+	_, err := DecodeFragment(frag)
+	if err == nil {
+		t.Fatal("expected an oversized fragment to be rejected")
+	}
+	if !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("expected error to mention the size limit, got: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package smart reads normalized health/SMART attributes from a storage
+// device, so benchmark runs can report temperature rise, wear, and
+// reallocation/media-error deltas induced by the workload alongside their
+// throughput/IOPS numbers.
+package smart
+
+import "runtime"
+
+// Info is a snapshot of a device's health attributes at one point in time.
+// Available is false if the device answered neither an NVMe Get Log Page
+// request nor an ATA SMART READ DATA request (e.g. a dir:// or s3://
+// target, or a device without SMART support) - callers should treat every
+// other field as meaningless in that case.
+type Info struct {
+	Available bool
+
+	TemperatureC       float64
+	PowerOnHours       uint64
+	PercentageUsed     float64 // NVMe "Percentage Used"; not read from ATA drives, where it stays 0
+	ReallocatedSectors uint64
+	MediaErrors        uint64 // NVMe Media and Data Integrity Errors; ATA Reallocated_Sector_Ct + UDMA_CRC_Error_Count sum
+	CriticalWarning    uint8  // NVMe critical warning bitmask; always 0 on ATA
+
+	// Raw holds every attribute a read could parse, keyed by NVMe log field
+	// name or ATA SMART attribute ID, for values with no normalized field
+	// above.
+	Raw map[string]uint64
+}
+
+// Delta is an after-minus-before comparison of two Info snapshots, as
+// produced by Diff.
+type Delta struct {
+	TemperatureC       float64
+	PowerOnHours       int64
+	PercentageUsed     float64
+	ReallocatedSectors int64
+	MediaErrors        int64
+}
+
+// Diff returns after-before for the normalized fields Read populates. It
+// returns the zero Delta if either snapshot is unavailable.
+func Diff(before, after Info) Delta {
+	if !before.Available || !after.Available {
+		return Delta{}
+	}
+	return Delta{
+		TemperatureC:       after.TemperatureC - before.TemperatureC,
+		PowerOnHours:       int64(after.PowerOnHours) - int64(before.PowerOnHours),
+		PercentageUsed:     after.PercentageUsed - before.PercentageUsed,
+		ReallocatedSectors: int64(after.ReallocatedSectors) - int64(before.ReallocatedSectors),
+		MediaErrors:        int64(after.MediaErrors) - int64(before.MediaErrors),
+	}
+}
+
+// Read snapshots devicePath's health attributes. It tries NVMe Admin Get
+// Log Page 0x02 first and falls back to ATA SMART READ DATA, since a raw
+// device path can name either kind of drive. No Identify command (NVMe or
+// ATA) is issued.
+func Read(devicePath string) (Info, error) {
+	if runtime.GOOS == "windows" {
+		return readWindows(devicePath)
+	}
+	return readLinux(devicePath)
+}
@@ -0,0 +1,245 @@
+//go:build linux
+// +build linux
+
+package smart
+
+import (
+	"encoding/binary"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Stub for cross-compilation; never called on linux (smart.go dispatches on
+// runtime.GOOS), but must exist so the platform-agnostic dispatcher builds.
+func readWindows(devicePath string) (Info, error) { return Info{}, nil }
+
+// nvmeIoctlAdminCmd is NVME_IOCTL_ADMIN_CMD, i.e. _IOWR('N', 0x41, struct
+// nvme_admin_cmd) from <linux/nvme_ioctl.h>.
+const nvmeIoctlAdminCmd = 0xC0484E41
+
+const (
+	nvmeAdminOpcodeGetLogPage = 0x02
+	nvmeAdminOpcodeIdentify   = 0x06
+
+	nvmeLogPageSMARTHealth    = 0x02
+	nvmeIdentifyCNSController = 0x01
+)
+
+// nvmeAdminCmd mirrors struct nvme_admin_cmd (aka nvme_passthru_cmd) from
+// <linux/nvme_ioctl.h>; its layout is fixed by the kernel ABI.
+type nvmeAdminCmd struct {
+	opcode      uint8
+	flags       uint8
+	rsvd1       uint16
+	nsid        uint32
+	cdw2        uint32
+	cdw3        uint32
+	metadata    uint64
+	addr        uint64
+	metadataLen uint32
+	dataLen     uint32
+	cdw10       uint32
+	cdw11       uint32
+	cdw12       uint32
+	cdw13       uint32
+	cdw14       uint32
+	cdw15       uint32
+	timeoutMs   uint32
+	result      uint32
+}
+
+// nvmeAdminPassthru issues cmd against fd via NVME_IOCTL_ADMIN_CMD.
+func nvmeAdminPassthru(fd uintptr, cmd *nvmeAdminCmd) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, nvmeIoctlAdminCmd, uintptr(unsafe.Pointer(cmd)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// readNVMe snapshots devicePath via NVMe Admin Get Log Page 0x02 (SMART /
+// Health Information), whose layout is fixed by the NVMe spec: byte 0 is
+// Critical Warning, bytes 1-2 Temperature (Kelvin), byte 5 Percentage Used,
+// bytes 32-47 Data Units Read, bytes 128-143 Power On Hours (128-bit LE),
+// bytes 160-175 Media and Data Integrity Errors (128-bit LE).
+func readNVMe(file *os.File) (Info, error) {
+	page := make([]byte, 512)
+	cmd := nvmeAdminCmd{
+		opcode:  nvmeAdminOpcodeGetLogPage,
+		nsid:    0xFFFFFFFF, // whole controller
+		addr:    uint64(uintptr(unsafe.Pointer(&page[0]))),
+		dataLen: uint32(len(page)),
+		// cdw10: bits 0-7 log page id, bits 16-27 number of dwords-1
+		cdw10: uint32(nvmeLogPageSMARTHealth) | (uint32(len(page)/4-1) << 16),
+	}
+	if err := nvmeAdminPassthru(file.Fd(), &cmd); err != nil {
+		return Info{}, err
+	}
+
+	info := Info{Available: true, Raw: map[string]uint64{}}
+	info.CriticalWarning = page[0]
+	tempKelvin := binary.LittleEndian.Uint16(page[1:3])
+	info.TemperatureC = float64(tempKelvin) - 273.15
+	info.PercentageUsed = float64(page[5])
+	info.PowerOnHours = le128ToUint64(page[128:144])
+	info.MediaErrors = le128ToUint64(page[160:176])
+	info.Raw["data_units_read"] = le128ToUint64(page[32:48])
+	info.Raw["data_units_written"] = le128ToUint64(page[48:64])
+	return info, nil
+}
+
+// le128ToUint64 reads a little-endian 128-bit counter and truncates it to
+// uint64, since the NVMe spec uses 128-bit counters for headroom no real
+// device approaches.
+func le128ToUint64(b []byte) uint64 {
+	return binary.LittleEndian.Uint64(b[0:8])
+}
+
+const sgIO = 0x2285 // SG_IO, from <scsi/sg.h>
+
+const (
+	sgDxferFromDev = -3
+	sgInfoOK       = 0x0
+)
+
+// sgIOHdr mirrors struct sg_io_hdr from <scsi/sg.h>; its layout is fixed by
+// the kernel ABI.
+type sgIOHdr struct {
+	interfaceID    int32
+	dxferDirection int32
+	cmdLen         uint8
+	mxSbLen        uint8
+	iovecCount     uint16
+	dxferLen       uint32
+	dxferp         uint64
+	cmdp           uint64
+	sbp            uint64
+	timeout        uint32
+	flags          uint32
+	packID         int32
+	usrPtr         uint64
+	status         uint8
+	maskedStatus   uint8
+	msgStatus      uint8
+	sbLenWr        uint8
+	hostStatus     uint16
+	driverStatus   uint16
+	resid          int32
+	duration       uint32
+	info           uint32
+}
+
+const (
+	ataOpASCII           = 0xA1 // ATA PASS-THROUGH(12)
+	ataProtocolPIODataIn = 4 << 1
+	ataCmdSMART          = 0xB0
+	ataSMARTReadData     = 0xD0
+	ataSMARTLBAMid       = 0x4F
+	ataSMARTLBAHigh      = 0xC2
+)
+
+// ataSMARTReadDataCDB builds the 12-byte ATA PASS-THROUGH(12) CDB for a
+// SMART READ DATA command, per the T10 SAT specification.
+func ataSMARTReadDataCDB() []byte {
+	cdb := make([]byte, 12)
+	cdb[0] = ataOpASCII
+	cdb[1] = ataProtocolPIODataIn
+	cdb[2] = 0x0E             // flags: t_length=2 (sector count), byte_block=1, t_dir=1 (in)
+	cdb[3] = ataSMARTReadData // features
+	cdb[4] = 1                // sector count
+	cdb[6] = ataSMARTLBAMid
+	cdb[7] = ataSMARTLBAHigh
+	cdb[9] = ataCmdSMART
+	return cdb
+}
+
+// readATA snapshots devicePath via ATA SMART READ DATA, whose 512-byte
+// response is a table of 30 12-byte attribute entries starting at offset 2:
+// {id, flags(2), value, worst, raw(6), reserved}. Attribute IDs follow the
+// common (non-vendor-standardized, but near-universal) convention: 194
+// Temperature, 9 Power_On_Hours, 5 Reallocated_Sector_Ct, 199 UDMA_CRC_Error_Count.
+func readATA(file *os.File) (Info, error) {
+	page := make([]byte, 512)
+	cdb := ataSMARTReadDataCDB()
+	sense := make([]byte, 32)
+
+	hdr := sgIOHdr{
+		interfaceID:    'S',
+		dxferDirection: sgDxferFromDev,
+		cmdLen:         uint8(len(cdb)),
+		mxSbLen:        uint8(len(sense)),
+		dxferLen:       uint32(len(page)),
+		dxferp:         uint64(uintptr(unsafe.Pointer(&page[0]))),
+		cmdp:           uint64(uintptr(unsafe.Pointer(&cdb[0]))),
+		sbp:            uint64(uintptr(unsafe.Pointer(&sense[0]))),
+		timeout:        3000,
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), sgIO, uintptr(unsafe.Pointer(&hdr)))
+	if errno != 0 {
+		return Info{}, errno
+	}
+
+	info := Info{Available: true, Raw: map[string]uint64{}}
+	for i := 0; i < 30; i++ {
+		entry := page[2+i*12 : 2+i*12+12]
+		id := entry[0]
+		if id == 0 {
+			continue
+		}
+		raw := le48ToUint64(entry[5:11])
+		info.Raw[attrName(id)] = raw
+
+		switch id {
+		case 194:
+			info.TemperatureC = float64(raw & 0xFF)
+		case 9:
+			info.PowerOnHours = raw
+		case 5:
+			info.ReallocatedSectors = raw
+			info.MediaErrors += raw
+		case 199:
+			info.MediaErrors += raw
+		}
+	}
+	return info, nil
+}
+
+// le48ToUint64 reads the 6-byte little-endian raw-value field SMART
+// attribute entries store their counter in.
+func le48ToUint64(b []byte) uint64 {
+	var v uint64
+	for i := 5; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
+
+func attrName(id byte) string {
+	switch id {
+	case 5:
+		return "Reallocated_Sector_Ct"
+	case 9:
+		return "Power_On_Hours"
+	case 194:
+		return "Temperature_Celsius"
+	case 199:
+		return "UDMA_CRC_Error_Count"
+	default:
+		return "attr_" + string(rune('0'+id/100)) + string(rune('0'+(id/10)%10)) + string(rune('0'+id%10))
+	}
+}
+
+func readLinux(devicePath string) (Info, error) {
+	file, err := os.OpenFile(devicePath, os.O_RDONLY, 0)
+	if err != nil {
+		return Info{}, err
+	}
+	defer file.Close()
+
+	if info, err := readNVMe(file); err == nil {
+		return info, nil
+	}
+	return readATA(file)
+}
@@ -0,0 +1,195 @@
+//go:build windows
+// +build windows
+
+package smart
+
+import (
+	"encoding/binary"
+	"syscall"
+	"unsafe"
+)
+
+// Stub for cross-compilation; never called on windows (smart.go dispatches
+// on runtime.GOOS), but must exist so the platform-agnostic dispatcher
+// builds.
+func readLinux(devicePath string) (Info, error) { return Info{}, nil }
+
+var (
+	kernel32DLL         = syscall.NewLazyDLL("kernel32.dll")
+	procCreateFileW     = kernel32DLL.NewProc("CreateFileW")
+	procDeviceIoControl = kernel32DLL.NewProc("DeviceIoControl")
+)
+
+const (
+	ioctlStorageQueryProperty = 0x2D1400
+	ioctlSCSIMiniport         = 0x4D008
+	smartRcvDriveData         = 0x7C088
+
+	storageDeviceProtocolSpecificProperty = 49
+	protocolTypeNvme                      = 3
+	nvmeStorageProtocolDataTypeLogPage    = 2
+)
+
+// storagePropertyQuery mirrors Windows' STORAGE_PROPERTY_QUERY header,
+// followed inline by a STORAGE_PROTOCOL_SPECIFIC_DATA block for NVMe
+// queries.
+type storagePropertyQuery struct {
+	PropertyID           uint32
+	QueryType            uint32
+	AdditionalParameters [48]byte
+}
+
+// storageProtocolSpecificData mirrors STORAGE_PROTOCOL_SPECIFIC_DATA, used
+// as the AdditionalParameters payload to ask for the NVMe Health Info log
+// page (log page 0x02) via IOCTL_STORAGE_QUERY_PROPERTY.
+type storageProtocolSpecificData struct {
+	ProtocolType                 uint32
+	DataType                     uint32
+	ProtocolDataRequestValue     uint32
+	ProtocolDataRequestSubValue  uint32
+	ProtocolDataOffset           uint32
+	ProtocolDataLength           uint32
+	FixedProtocolReturnData      uint32
+	ProtocolDataRequestSubValue2 uint32
+	ProtocolDataRequestSubValue3 uint32
+}
+
+// readNVMeWindows asks the miniport for the NVMe Health Info log page (the
+// same data ATA/NVMe passthrough on Linux reads from Get Log Page 0x02) via
+// IOCTL_STORAGE_QUERY_PROPERTY / StorageDeviceProtocolSpecificProperty.
+func readNVMeWindows(handle syscall.Handle) (Info, error) {
+	const logPageSize = 512
+	type req struct {
+		query storagePropertyQuery
+		data  storageProtocolSpecificData
+		log   [logPageSize]byte
+	}
+	var buf req
+	buf.query.PropertyID = storageDeviceProtocolSpecificProperty
+	buf.data.ProtocolType = protocolTypeNvme
+	buf.data.DataType = nvmeStorageProtocolDataTypeLogPage
+	buf.data.ProtocolDataRequestValue = 0x02 // SMART / Health Information log page
+	buf.data.ProtocolDataOffset = uint32(unsafe.Sizeof(buf.data))
+	buf.data.ProtocolDataLength = logPageSize
+
+	var bytesReturned uint32
+	r1, _, errno := procDeviceIoControl.Call(
+		uintptr(handle),
+		uintptr(ioctlStorageQueryProperty),
+		uintptr(unsafe.Pointer(&buf)),
+		unsafe.Sizeof(buf),
+		uintptr(unsafe.Pointer(&buf)),
+		unsafe.Sizeof(buf),
+		uintptr(unsafe.Pointer(&bytesReturned)),
+		0,
+	)
+	if r1 == 0 {
+		return Info{}, errno
+	}
+
+	page := buf.log[:]
+	info := Info{Available: true, Raw: map[string]uint64{}}
+	info.CriticalWarning = page[0]
+	info.TemperatureC = float64(binary.LittleEndian.Uint16(page[1:3])) - 273.15
+	info.PercentageUsed = float64(page[5])
+	info.PowerOnHours = binary.LittleEndian.Uint64(page[128:136])
+	info.MediaErrors = binary.LittleEndian.Uint64(page[160:168])
+	return info, nil
+}
+
+// smartRcvDriveDataBuffer mirrors the SENDCMDOUTPARAMS wrapper SMART_RCV_DRIVE_DATA
+// returns: a header followed by the 512-byte SMART data page.
+type smartRcvDriveDataBuffer struct {
+	BufferSize   uint32
+	DriverStatus [4]byte
+	Data         [512]byte
+}
+
+// readATAWindows asks the ATA miniport for the SMART data page via
+// SMART_RCV_DRIVE_DATA, the Windows equivalent of Linux's ATA SMART READ
+// DATA passthrough, and parses it the same way.
+func readATAWindows(handle syscall.Handle) (Info, error) {
+	var out smartRcvDriveDataBuffer
+	var bytesReturned uint32
+	r1, _, errno := procDeviceIoControl.Call(
+		uintptr(handle),
+		uintptr(smartRcvDriveData),
+		0, 0,
+		uintptr(unsafe.Pointer(&out)),
+		unsafe.Sizeof(out),
+		uintptr(unsafe.Pointer(&bytesReturned)),
+		0,
+	)
+	if r1 == 0 {
+		return Info{}, errno
+	}
+
+	page := out.Data[:]
+	info := Info{Available: true, Raw: map[string]uint64{}}
+	for i := 0; i < 30; i++ {
+		entry := page[2+i*12 : 2+i*12+12]
+		id := entry[0]
+		if id == 0 {
+			continue
+		}
+		var raw uint64
+		for j := 5; j >= 0; j-- {
+			raw = raw<<8 | uint64(entry[j])
+		}
+		info.Raw[attrNameWindows(id)] = raw
+		switch id {
+		case 194:
+			info.TemperatureC = float64(raw & 0xFF)
+		case 9:
+			info.PowerOnHours = raw
+		case 5:
+			info.ReallocatedSectors = raw
+			info.MediaErrors += raw
+		case 199:
+			info.MediaErrors += raw
+		}
+	}
+	return info, nil
+}
+
+func attrNameWindows(id byte) string {
+	switch id {
+	case 5:
+		return "Reallocated_Sector_Ct"
+	case 9:
+		return "Power_On_Hours"
+	case 194:
+		return "Temperature_Celsius"
+	case 199:
+		return "UDMA_CRC_Error_Count"
+	default:
+		return "attr_" + string(rune('0'+id/100)) + string(rune('0'+(id/10)%10)) + string(rune('0'+id%10))
+	}
+}
+
+func readWindows(devicePath string) (Info, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(devicePath)
+	if err != nil {
+		return Info{}, err
+	}
+
+	r1, _, errno := procCreateFileW.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(syscall.GENERIC_READ|syscall.GENERIC_WRITE),
+		uintptr(syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE),
+		0,
+		uintptr(syscall.OPEN_EXISTING),
+		0,
+		0,
+	)
+	handle := syscall.Handle(r1)
+	if handle == syscall.InvalidHandle {
+		return Info{}, errno
+	}
+	defer syscall.CloseHandle(handle)
+
+	if info, err := readNVMeWindows(handle); err == nil {
+		return info, nil
+	}
+	return readATAWindows(handle)
+}
@@ -0,0 +1,118 @@
+package gui
+
+import (
+	"bytes"
+
+	chart "github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+)
+
+// Fixed panel layout shared between the chart image and the hover overlay
+// redrawGraph draws on top of it, so the two stay pixel-aligned.
+const (
+	graphPanelWidth  = 1100
+	graphPanelHeight = 150
+	graphPlotLeft    = 40
+	graphPlotTop     = 35
+	graphPlotWidth   = 1050
+	graphPlotHeight  = 100
+)
+
+var (
+	readChartColor       = drawing.Color{R: 100, G: 149, B: 237, A: 255}
+	writeChartColor      = drawing.Color{R: 255, G: 20, B: 147, A: 255}
+	gridLineChartColor   = drawing.Color{R: 60, G: 64, B: 72, A: 255}
+	axisLabelChartColor  = drawing.Color{R: 150, G: 150, B: 150, A: 255}
+	panelBackgroundColor = drawing.Color{R: 40, G: 44, B: 52, A: 255}
+)
+
+// chartRenderer turns one metric's Read/Write series into a PNG image via
+// go-chart, replacing the hundreds of canvas.Line objects redrawGraph used
+// to allocate by hand on every hover-driven redraw. Its plot area is pinned
+// to graphPlotLeft/Top/Width/Height (via Canvas padding) so the hover
+// overlay drawn on top by redrawGraph still lines up with the data.
+type chartRenderer struct {
+	title    string
+	maxValue float64
+}
+
+func newChartRenderer(title string, maxValue float64) *chartRenderer {
+	return &chartRenderer{title: title, maxValue: maxValue}
+}
+
+// render builds both series from data - using computeSegments so a
+// partially filled window still gets the same zero-interpolated leading
+// edge and flat trailing edge as the rest of the GUI's graphs - and renders
+// them to a PNG sized to the panel's fixed 1100x150 layout.
+func (cr *chartRenderer) render(data []DataPoint, maxDataPoints int) ([]byte, error) {
+	readXs, readYs := segmentsToPoints(computeSegments(data, maxDataPoints, float32(maxDataPoints), func(dp DataPoint) float64 { return dp.ReadValue }))
+	writeXs, writeYs := segmentsToPoints(computeSegments(data, maxDataPoints, float32(maxDataPoints), func(dp DataPoint) float64 { return dp.WriteValue }))
+
+	series := make([]chart.Series, 0, 2)
+	if len(readXs) > 0 {
+		series = append(series, chart.ContinuousSeries{
+			Name:    "Read",
+			Style:   chart.Style{StrokeColor: readChartColor, StrokeWidth: 2},
+			XValues: readXs,
+			YValues: readYs,
+		})
+	}
+	if len(writeXs) > 0 {
+		series = append(series, chart.ContinuousSeries{
+			Name:    "Write",
+			Style:   chart.Style{StrokeColor: writeChartColor, StrokeWidth: 2},
+			XValues: writeXs,
+			YValues: writeYs,
+		})
+	}
+
+	c := chart.Chart{
+		Title:      cr.title,
+		TitleStyle: chart.Style{FontColor: drawing.ColorWhite, FontSize: 14},
+		Width:      graphPanelWidth,
+		Height:     graphPanelHeight,
+		Background: chart.Style{FillColor: panelBackgroundColor},
+		Canvas: chart.Style{
+			FillColor: panelBackgroundColor,
+			Padding: chart.Box{
+				Top:    graphPlotTop,
+				Left:   graphPlotLeft,
+				Right:  graphPanelWidth - graphPlotLeft - graphPlotWidth,
+				Bottom: graphPanelHeight - graphPlotTop - graphPlotHeight,
+			},
+		},
+		XAxis: chart.XAxis{
+			Range: &chart.ContinuousRange{Min: 0, Max: float64(maxDataPoints)},
+			Style: chart.Style{Hidden: true},
+		},
+		YAxis: chart.YAxis{
+			Range: &chart.ContinuousRange{Min: 0, Max: cr.maxValue},
+			Style: chart.Style{StrokeColor: gridLineChartColor, FontColor: axisLabelChartColor, FontSize: 10},
+		},
+		Series: series,
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := c.Render(chart.PNG, buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// segmentsToPoints flattens computeSegments' contiguous segment list into
+// the XValues/YValues go-chart's ContinuousSeries expects.
+func segmentsToPoints(segs []segment) (xs, ys []float64) {
+	if len(segs) == 0 {
+		return nil, nil
+	}
+	xs = make([]float64, 0, len(segs)+1)
+	ys = make([]float64, 0, len(segs)+1)
+	for _, s := range segs {
+		xs = append(xs, float64(s.X0))
+		ys = append(ys, s.Y0)
+	}
+	last := segs[len(segs)-1]
+	xs = append(xs, float64(last.X1))
+	ys = append(ys, last.Y1)
+	return xs, ys
+}
@@ -0,0 +1,289 @@
+package gui
+
+import (
+	"4corners/benchmark"
+	"4corners/device"
+	"4corners/logging"
+	"4corners/presets"
+	"encoding/json"
+	"fmt"
+)
+
+// Controller holds the benchmark session state that's independent of how a
+// caller talks to it. Both the webview JS bindings (GUI, in webview.go) and
+// the headless HTTP/WebSocket server (cmd/4cornerscli's `serve` subcommand)
+// drive the same Controller, so "is a run in progress" and "which devices
+// are selected" can't drift between the two transports.
+type Controller struct {
+	benchEngine     *benchmark.Engine
+	selectedDevices []string
+	isRunning       bool
+	savePath        string
+
+	runID      int64
+	timeSeries *benchmark.TimeSeries
+}
+
+// NewController creates a Controller with a fresh benchmark.Engine, ready
+// for either transport layer to bind against.
+func NewController() *Controller {
+	return &Controller{
+		benchEngine: benchmark.NewEngine(),
+		timeSeries:  benchmark.NewTimeSeries(60),
+	}
+}
+
+// ListDevices returns the raw device.ListDevices() result as JSON, the same
+// shape the device picker dialog has always expected.
+func (c *Controller) ListDevices() string {
+	devices, err := device.ListDevices()
+	if err != nil {
+		return fmt.Sprintf(`{"error": "%s"}`, err.Error())
+	}
+	data, _ := json.Marshal(devices)
+	return string(data)
+}
+
+// SelectDevices replaces the set of devices to benchmark. devicesJSON is a
+// JSON array of {"path": ..., "name": ...} objects built by a device
+// picker - a device path is opaque to callers (bare path, dir://, s3://,
+// ...), so the whole set comes in as one call rather than accumulating via
+// repeated single-path calls. It returns the selected devices' display
+// names for callers that want to echo the selection back to a user.
+func (c *Controller) SelectDevices(devicesJSON string) ([]string, error) {
+	var devices []struct {
+		Path string `json:"path"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(devicesJSON), &devices); err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no devices selected")
+	}
+
+	c.selectedDevices = c.selectedDevices[:0]
+	names := make([]string, 0, len(devices))
+	for _, d := range devices {
+		c.selectedDevices = append(c.selectedDevices, d.Path)
+		names = append(names, d.Name)
+	}
+	return names, nil
+}
+
+// SetDevices replaces the selected device set directly, bypassing the JSON
+// picker payload - used by flows that already have a bare path, such as
+// "select the file device I just created".
+func (c *Controller) SetDevices(devices []string) {
+	c.selectedDevices = devices
+}
+
+// SelectedDevices returns the currently selected device set.
+func (c *Controller) SelectedDevices() []string {
+	return c.selectedDevices
+}
+
+// PrepDevice fills every selected device with random data in turn -
+// benchmark.Engine.PrepDevice itself stays single-device, so a
+// multi-device selection just loops the existing call.
+func (c *Controller) PrepDevice(progressCallback func(string)) error {
+	if len(c.selectedDevices) == 0 {
+		return fmt.Errorf("no device selected")
+	}
+
+	logged := func(msg string) {
+		c.benchEngine.Logger.Infof("prep", "%s", msg)
+		progressCallback(msg)
+	}
+
+	for _, dev := range c.selectedDevices {
+		logged(fmt.Sprintf("Preparing %s...", dev))
+		if err := c.benchEngine.PrepDevice(dev, logged); err != nil {
+			c.benchEngine.Logger.Errorf("prep", "prep failed: %v", err)
+			return fmt.Errorf("prep failed: %w", err)
+		}
+	}
+	logged("Device prep completed")
+	return nil
+}
+
+// RunBenchmark fills in the selected device(s) on benchConfig, runs the
+// configured tests, and forwards progress text and structured Events to
+// the caller's callbacks as the run proceeds. If a save location is set,
+// the report is written before RunBenchmark returns.
+func (c *Controller) RunBenchmark(benchConfig benchmark.Config, eventCallback benchmark.EventCallback, progressCallback func(string)) (*benchmark.Results, error) {
+	if len(c.selectedDevices) == 0 {
+		return nil, fmt.Errorf("no device selected")
+	}
+
+	benchConfig.Device = c.selectedDevices[0]
+	if len(c.selectedDevices) > 1 {
+		benchConfig.Devices = c.selectedDevices
+	}
+
+	c.runID++
+	c.timeSeries.Reset()
+
+	logged := func(msg string) {
+		c.benchEngine.Logger.Infof("run", "%s", msg)
+		progressCallback(msg)
+	}
+
+	c.isRunning = true
+	defer func() { c.isRunning = false }()
+
+	c.benchEngine.OnEvent(func(ev benchmark.Event) {
+		c.timeSeries.Record(ev)
+		eventCallback(ev)
+	})
+	results, err := c.benchEngine.RunBenchmark(benchConfig, logged)
+	if err == nil {
+		if _, saveErr := results.SaveRun(); saveErr != nil {
+			logged(fmt.Sprintf("Saving run to history failed: %v", saveErr))
+		}
+		if c.savePath != "" {
+			if saveErr := results.SaveReport(c.savePath); saveErr != nil {
+				logged(fmt.Sprintf("Results save failed: %v", saveErr))
+			} else {
+				logged(fmt.Sprintf("Results saved to: %s", c.savePath))
+			}
+		}
+	} else {
+		c.benchEngine.Logger.Errorf("run", "benchmark failed: %v", err)
+	}
+	return results, err
+}
+
+// ListRuns returns metadata for every run in the history library, newest
+// first, as JSON - the same shape the Runs panel's device picker-style list
+// expects.
+func (c *Controller) ListRuns() (string, error) {
+	runs, err := benchmark.ListRuns()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// LoadRunOverlay loads the run at path and returns its recorded sample
+// events as JSON, for a GUI to draw as a translucent overlay on the live
+// graphs without needing its own channel-draining code.
+func (c *Controller) LoadRunOverlay(path string) (string, error) {
+	run, err := benchmark.LoadRun(path)
+	if err != nil {
+		return "", err
+	}
+
+	samples := make([]benchmark.SampleEvent, 0, len(run.Events))
+	for ev := range run.OverlayEvents() {
+		samples = append(samples, ev)
+	}
+
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ListPresets returns every saved preset, sorted by name, as JSON - for the
+// preset dropdown above the config grid.
+func (c *Controller) ListPresets() (string, error) {
+	store, err := presets.NewFileStore()
+	if err != nil {
+		return "", err
+	}
+	list, err := store.List()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SavePreset saves (or overwrites) a named preset.
+func (c *Controller) SavePreset(p presets.Preset) error {
+	store, err := presets.NewFileStore()
+	if err != nil {
+		return err
+	}
+	return store.Save(p)
+}
+
+// DeletePreset removes the saved preset named name.
+func (c *Controller) DeletePreset(name string) error {
+	store, err := presets.NewFileStore()
+	if err != nil {
+		return err
+	}
+	return store.Delete(name)
+}
+
+// Logger returns the Controller's structured logger, so a transport layer
+// (the webview GUI, the headless serve command) can register its own sink -
+// a WebSocket fan-out, an eval-to-console bridge, a log file - alongside the
+// default stdout sink every Engine is created with.
+func (c *Controller) Logger() *logging.Logger {
+	return c.benchEngine.Logger
+}
+
+// CurrentRunID returns an identifier that changes every time RunBenchmark
+// starts a new run, so a streaming client can tell a frame belonging to a
+// run it's already stopped watching apart from one belonging to the run
+// it's currently displaying.
+func (c *Controller) CurrentRunID() int64 {
+	return c.runID
+}
+
+// TimeSeriesSnapshot returns the current run's per-phase sample history as
+// JSON, tagged with CurrentRunID, for a client that connects (or
+// reconnects) mid-run to catch up on the sparkline's recent history instead
+// of starting from a blank panel.
+func (c *Controller) TimeSeriesSnapshot() string {
+	snapshot := struct {
+		Type   string                        `json:"type"`
+		RunID  int64                         `json:"runId"`
+		Phases map[string][]benchmark.Sample `json:"phases"`
+	}{
+		Type:   "snapshot",
+		RunID:  c.runID,
+		Phases: c.timeSeries.Snapshot(),
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return `{"type":"snapshot","phases":{}}`
+	}
+	return string(data)
+}
+
+// StopBenchmark cancels a run in progress; a no-op if nothing is running.
+func (c *Controller) StopBenchmark() {
+	if c.isRunning {
+		c.benchEngine.Stop()
+	}
+}
+
+// IsRunning reports whether a benchmark is currently in progress.
+func (c *Controller) IsRunning() bool {
+	return c.isRunning
+}
+
+// SetSaveLocation sets the path RunBenchmark writes its report to once a
+// run completes; an empty path disables auto-saving.
+func (c *Controller) SetSaveLocation(path string) {
+	c.savePath = path
+}
+
+// SavePath returns the path a completed run's report was (or will be) saved
+// to, or "" if auto-saving is disabled.
+func (c *Controller) SavePath() string {
+	return c.savePath
+}
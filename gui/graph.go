@@ -90,6 +90,9 @@ func (o *interactiveOverlay) MouseMoved(ev *desktop.MouseEvent) {
 	o.panel.mu.Lock()
 	o.panel.hoverX = ev.Position.X
 	o.panel.hoverActive = true
+	if o.panel.dragging {
+		o.panel.panBy(ev.Position.X - o.panel.dragStartX)
+	}
 	o.panel.mu.Unlock()
 	o.panel.redrawAll()
 }
@@ -123,27 +126,51 @@ func (r *interactiveOverlayRenderer) Destroy() {}
 
 type GraphPanel struct {
 	container          *fyne.Container
-	
+
 	throughputContainer fyne.CanvasObject
 	iopsContainer       fyne.CanvasObject
 	latencyContainer    fyne.CanvasObject
-	
+
 	throughputInnerContainer *fyne.Container
 	iopsInnerContainer       *fyne.Container
 	latencyInnerContainer    *fyne.Container
-	
+
+	// throughputData/iopsData/latencyData hold the whole run's history, not
+	// just the visible window - visibleRange picks the [start,end) slice
+	// redrawAll actually draws, so pan/zoom can reach back into samples
+	// that have scrolled off the live tail.
 	throughputData      []DataPoint
 	iopsData            []DataPoint
 	latencyData         []DataPoint
-	
+
 	maxDataPoints       int
 	maxThroughput       float64
 	maxIOPS             float64
 	maxLatency          float64
-	
+
 	hoverX              float32
 	hoverActive         bool
-	
+
+	// liveTail true (the default) means visibleRange always returns the
+	// rolling maxDataPoints-wide tail, same as before pan/zoom existed.
+	// Any pan, zoom, or selection drag sets it false and pins the window to
+	// viewStart/viewEnd instead; double-clicking the overlay resets it.
+	liveTail   bool
+	viewStart  int
+	viewEnd    int
+
+	dragging      bool
+	dragStartX    float32
+	dragStartView [2]int
+
+	selecting      bool
+	selectStartX   float32
+	selectionStats *selectionStats
+
+	// replaying true means the data was loaded by LoadRun rather than fed by
+	// a live benchmark; UpdateData is a no-op until EnterLiveMode clears it.
+	replaying bool
+
 	mu                  sync.Mutex
 }
 
@@ -162,6 +189,7 @@ func NewGraphPanel() *GraphPanel {
 		maxThroughput:  2000,    // Start at 2000 MB/s
 		maxIOPS:        100000,  // Start at 100,000 IOPS
 		maxLatency:     5,       // Start at 5 ms
+		liveTail:       true,
 	}
 	
 	// Create three separate graph containers
@@ -205,67 +233,71 @@ func NewGraphPanel() *GraphPanel {
 }
 
 func (gp *GraphPanel) createEmptyGraph(title string, maxValue float64) (*fyne.Container, fyne.CanvasObject) {
-	objects := make([]fyne.CanvasObject, 0)
-	
-	// Background rectangle
-	bg := canvas.NewRectangle(color.RGBA{R: 40, G: 44, B: 52, A: 255})
-	bg.Resize(fyne.NewSize(1100, 150))
-	bg.Move(fyne.NewPos(0, 0))
-	objects = append(objects, bg)
-	
-	// Title
-	titleText := canvas.NewText(title, color.White)
-	titleText.TextSize = 14
-	titleText.TextStyle = fyne.TextStyle{Bold: true}
-	titleText.Move(fyne.NewPos(10, 10))
-	objects = append(objects, titleText)
-	
-	// Y-axis max value label
-	maxLabel := canvas.NewText(fmt.Sprintf("%.0f", maxValue), color.RGBA{R: 150, G: 150, B: 150, A: 255})
-	maxLabel.TextSize = 10
-	maxLabel.Move(fyne.NewPos(5, 35))
-	objects = append(objects, maxLabel)
-	
-	// Y-axis min value label
-	minLabel := canvas.NewText("0", color.RGBA{R: 150, G: 150, B: 150, A: 255})
-	minLabel.TextSize = 10
-	minLabel.Move(fyne.NewPos(5, 135))
-	objects = append(objects, minLabel)
-	
-	// Grid lines (5 horizontal lines)
-	for i := 0; i <= 4; i++ {
-		y := float32(35 + i*25)
-		line := canvas.NewLine(color.RGBA{R: 60, G: 64, B: 72, A: 255})
-		line.Position1 = fyne.NewPos(40, y)
-		line.Position2 = fyne.NewPos(1090, y)
-		line.StrokeWidth = 1
-		objects = append(objects, line)
+	objects := gp.buildGraphObjects(nil, maxValue, title)
+
+	c := container.NewWithoutLayout(objects...)
+	c.Resize(fyne.NewSize(graphPanelWidth, graphPanelHeight))
+
+	// Wrap in fixed size container to enforce dimensions
+	wrapper := newFixedSizeContainer(c, fyne.NewSize(graphPanelWidth, graphPanelHeight))
+
+	return c, wrapper
+}
+
+// buildGraphObjects renders data as a single chart.Chart image (background,
+// title, grid, axis ticks, and both Read/Write series all come from
+// go-chart now) plus the small current-value and legend text labels that
+// sit on top of it. redrawGraph adds the hover line/tooltip over the
+// result; this is shared with createEmptyGraph so the initial empty panel
+// looks identical to a freshly redrawn one.
+func (gp *GraphPanel) buildGraphObjects(data []DataPoint, maxValue float64, title string) []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, 0, 5)
+
+	if png, err := newChartRenderer(title, maxValue).render(data, gp.maxDataPoints); err == nil {
+		img := canvas.NewImageFromResource(fyne.NewStaticResource(title+".png", png))
+		img.FillMode = canvas.ImageFillOriginal
+		img.Resize(fyne.NewSize(graphPanelWidth, graphPanelHeight))
+		img.Move(fyne.NewPos(0, 0))
+		objects = append(objects, img)
 	}
-	
-	// Legend labels
+
+	// Current value labels
+	if len(data) > 0 {
+		last := data[len(data)-1]
+
+		readValueLabel := canvas.NewText(fmt.Sprintf("Read: %.1f", last.ReadValue), color.RGBA{R: 100, G: 149, B: 237, A: 255})
+		readValueLabel.TextSize = 10
+		readValueLabel.Move(fyne.NewPos(250, 10))
+		objects = append(objects, readValueLabel)
+
+		writeValueLabel := canvas.NewText(fmt.Sprintf("Write: %.1f", last.WriteValue), color.RGBA{R: 255, G: 20, B: 147, A: 255})
+		writeValueLabel.TextSize = 10
+		writeValueLabel.Move(fyne.NewPos(350, 10))
+		objects = append(objects, writeValueLabel)
+	}
+
+	// Legend
 	readLegend := canvas.NewText("Read", color.RGBA{R: 100, G: 149, B: 237, A: 255})
 	readLegend.TextSize = 10
 	readLegend.Move(fyne.NewPos(950, 10))
 	objects = append(objects, readLegend)
-	
+
 	writeLegend := canvas.NewText("Write", color.RGBA{R: 255, G: 20, B: 147, A: 255})
 	writeLegend.TextSize = 10
 	writeLegend.Move(fyne.NewPos(1020, 10))
 	objects = append(objects, writeLegend)
-	
-	c := container.NewWithoutLayout(objects...)
-	c.Resize(fyne.NewSize(1100, 150))
-	
-	// Wrap in fixed size container to enforce dimensions
-	wrapper := newFixedSizeContainer(c, fyne.NewSize(1100, 150))
-	
-	return c, wrapper
+
+	return objects
 }
 
 func (gp *GraphPanel) UpdateData(time, throughput, iops, latency float64, isWrite bool) {
 	gp.mu.Lock()
 	defer gp.mu.Unlock()
 	
+	if gp.replaying {
+		return
+	}
+
 	// Add data points (avoid duplicates for same time)
 	if len(gp.throughputData) == 0 || gp.throughputData[len(gp.throughputData)-1].Time != time {
 		readTP, writeTP := 0.0, 0.0
@@ -285,32 +317,70 @@ func (gp *GraphPanel) UpdateData(time, throughput, iops, latency float64, isWrit
 		gp.throughputData = append(gp.throughputData, DataPoint{time, readTP, writeTP})
 		gp.iopsData = append(gp.iopsData, DataPoint{time, readIOPS, writeIOPS})
 		gp.latencyData = append(gp.latencyData, DataPoint{time, readLat, writeLat})
-		
-		// Keep only last N points
-		if len(gp.throughputData) > gp.maxDataPoints {
-			gp.throughputData = gp.throughputData[1:]
-			gp.iopsData = gp.iopsData[1:]
-			gp.latencyData = gp.latencyData[1:]
-		}
-		
+
 		// Check for scaling
 		gp.checkAndScale()
-		
+
 		// Redraw all three graphs
 		gp.redrawAll()
 	}
 }
 
+// visibleRange returns the [start,end) window into the full-history *Data
+// slices that should currently be drawn: the live rolling tail (today's
+// default behavior) while liveTail is true, or the user's pan/zoom/selection
+// window otherwise. Like the rest of GraphPanel's non-exported helpers, it
+// assumes the caller already holds (or doesn't need) gp.mu.
+func (gp *GraphPanel) visibleRange(total int) (int, int) {
+	if total == 0 {
+		return 0, 0
+	}
+	if gp.liveTail {
+		end := total
+		start := end - gp.maxDataPoints
+		if start < 0 {
+			start = 0
+		}
+		return start, end
+	}
+
+	start, end := gp.viewStart, gp.viewEnd
+	if end > total {
+		end = total
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start >= end {
+		start = end - 1
+		if start < 0 {
+			start = 0
+		}
+	}
+	return start, end
+}
+
+// tailSlice returns the last maxDataPoints samples of data (or all of it,
+// if there aren't that many yet) - the window checkAndScale bases its
+// auto-scaling on, regardless of what pan/zoom is currently showing.
+func (gp *GraphPanel) tailSlice(data []DataPoint) []DataPoint {
+	if len(data) <= gp.maxDataPoints {
+		return data
+	}
+	return data[len(data)-gp.maxDataPoints:]
+}
+
 func (gp *GraphPanel) redrawAll() {
-	gp.redrawGraph(gp.throughputInnerContainer, gp.throughputData, gp.maxThroughput, "Throughput (MB/s)")
-	gp.redrawGraph(gp.iopsInnerContainer, gp.iopsData, gp.maxIOPS, "IOPS")
-	gp.redrawGraph(gp.latencyInnerContainer, gp.latencyData, gp.maxLatency, "Latency (ms)")
+	start, end := gp.visibleRange(len(gp.throughputData))
+	gp.redrawGraph(gp.throughputInnerContainer, gp.throughputData, start, end, gp.maxThroughput, "Throughput (MB/s)")
+	gp.redrawGraph(gp.iopsInnerContainer, gp.iopsData, start, end, gp.maxIOPS, "IOPS")
+	gp.redrawGraph(gp.latencyInnerContainer, gp.latencyData, start, end, gp.maxLatency, "Latency (ms)")
 }
 
 func (gp *GraphPanel) checkAndScale() {
 	// Throughput: scale up if any value exceeds 90%, scale down if max value is below 60%
 	maxThroughput := 0.0
-	for _, dp := range gp.throughputData {
+	for _, dp := range gp.tailSlice(gp.throughputData) {
 		if dp.ReadValue > maxThroughput {
 			maxThroughput = dp.ReadValue
 		}
@@ -335,7 +405,7 @@ func (gp *GraphPanel) checkAndScale() {
 	
 	// IOPS: scale up if any value exceeds 90%, scale down if max value is below 60%
 	maxIOPS := 0.0
-	for _, dp := range gp.iopsData {
+	for _, dp := range gp.tailSlice(gp.iopsData) {
 		if dp.ReadValue > maxIOPS {
 			maxIOPS = dp.ReadValue
 		}
@@ -360,7 +430,7 @@ func (gp *GraphPanel) checkAndScale() {
 	
 	// Latency: scale up if any value exceeds 90%, scale down if max value is below 60%
 	maxLatency := 0.0
-	for _, dp := range gp.latencyData {
+	for _, dp := range gp.tailSlice(gp.latencyData) {
 		if dp.ReadValue > maxLatency {
 			maxLatency = dp.ReadValue
 		}
@@ -384,148 +454,18 @@ func (gp *GraphPanel) checkAndScale() {
 	}
 }
 
-func (gp *GraphPanel) redrawGraph(graphContainer *fyne.Container, data []DataPoint, maxValue float64, title string) {
-	objects := make([]fyne.CanvasObject, 0)
-	
-	// Background
-	bg := canvas.NewRectangle(color.RGBA{R: 40, G: 44, B: 52, A: 255})
-	bg.Resize(fyne.NewSize(1100, 150))
-	bg.Move(fyne.NewPos(0, 0))
-	objects = append(objects, bg)
-	
-	// Title
-	titleText := canvas.NewText(title, color.White)
-	titleText.TextSize = 14
-	titleText.TextStyle = fyne.TextStyle{Bold: true}
-	titleText.Move(fyne.NewPos(10, 10))
-	objects = append(objects, titleText)
-	
-	// Y-axis labels
-	maxLabel := canvas.NewText(fmt.Sprintf("%.0f", maxValue), color.RGBA{R: 150, G: 150, B: 150, A: 255})
-	maxLabel.TextSize = 10
-	maxLabel.Move(fyne.NewPos(5, 35))
-	objects = append(objects, maxLabel)
-	
-	minLabel := canvas.NewText("0", color.RGBA{R: 150, G: 150, B: 150, A: 255})
-	minLabel.TextSize = 10
-	minLabel.Move(fyne.NewPos(5, 135))
-	objects = append(objects, minLabel)
-	
-	// Grid lines
-	for i := 0; i <= 4; i++ {
-		y := float32(35 + i*25)
-		line := canvas.NewLine(color.RGBA{R: 60, G: 64, B: 72, A: 255})
-		line.Position1 = fyne.NewPos(40, y)
-		line.Position2 = fyne.NewPos(1090, y)
-		line.StrokeWidth = 1
-		objects = append(objects, line)
-	}
-	
-	// Current value labels
-	if len(data) > 0 {
-		last := data[len(data)-1]
-		
-		readValueLabel := canvas.NewText(fmt.Sprintf("Read: %.1f", last.ReadValue), color.RGBA{R: 100, G: 149, B: 237, A: 255})
-		readValueLabel.TextSize = 10
-		readValueLabel.Move(fyne.NewPos(250, 10))
-		objects = append(objects, readValueLabel)
-		
-		writeValueLabel := canvas.NewText(fmt.Sprintf("Write: %.1f", last.WriteValue), color.RGBA{R: 255, G: 20, B: 147, A: 255})
-		writeValueLabel.TextSize = 10
-		writeValueLabel.Move(fyne.NewPos(350, 10))
-		objects = append(objects, writeValueLabel)
-	}
-	
-	// Legend
-	readLegend := canvas.NewText("Read", color.RGBA{R: 100, G: 149, B: 237, A: 255})
-	readLegend.TextSize = 10
-	readLegend.Move(fyne.NewPos(950, 10))
-	objects = append(objects, readLegend)
-	
-	writeLegend := canvas.NewText("Write", color.RGBA{R: 255, G: 20, B: 147, A: 255})
-	writeLegend.TextSize = 10
-	writeLegend.Move(fyne.NewPos(1020, 10))
-	objects = append(objects, writeLegend)
-	
-	// Draw data lines (if we have at least 2 points)
-	if len(data) >= 2 {
-		graphHeight := float32(100)  // Height of plot area
-		graphTop := float32(35)      // Top of plot area
-		graphLeft := float32(40)     // Left edge of plot area
-		graphWidth := float32(1050)  // Width of plot area (1090 - 40)
-		
-		dataPoints := len(data)
-		
-		// Blue line for Read values - RIGHT TO LEFT (newest on right)
-		readColor := color.RGBA{R: 100, G: 149, B: 237, A: 255}
-		for i := 0; i < len(data)-1; i++ {
-			// Calculate X position from RIGHT (newest = rightmost)
-			x1 := graphLeft + graphWidth - float32(dataPoints-1-i)*graphWidth/float32(gp.maxDataPoints-1)
-			x2 := graphLeft + graphWidth - float32(dataPoints-2-i)*graphWidth/float32(gp.maxDataPoints-1)
-			
-			// Calculate Y position (inverted - 0 at bottom)
-			y1 := graphTop + graphHeight - float32(data[i].ReadValue/maxValue)*graphHeight
-			y2 := graphTop + graphHeight - float32(data[i+1].ReadValue/maxValue)*graphHeight
-			
-			// Clamp to graph area
-			if y1 < graphTop {
-				y1 = graphTop
-			}
-			if y2 < graphTop {
-				y2 = graphTop
-			}
-			if y1 > graphTop+graphHeight {
-				y1 = graphTop + graphHeight
-			}
-			if y2 > graphTop+graphHeight {
-				y2 = graphTop + graphHeight
-			}
-			
-			line := canvas.NewLine(readColor)
-			line.Position1 = fyne.NewPos(x1, y1)
-			line.Position2 = fyne.NewPos(x2, y2)
-			line.StrokeWidth = 2
-			objects = append(objects, line)
-		}
-		
-		// Pink line for Write values - RIGHT TO LEFT (newest on right)
-		writeColor := color.RGBA{R: 255, G: 20, B: 147, A: 255}
-		for i := 0; i < len(data)-1; i++ {
-			// Calculate X position from RIGHT (newest = rightmost)
-			x1 := graphLeft + graphWidth - float32(dataPoints-1-i)*graphWidth/float32(gp.maxDataPoints-1)
-			x2 := graphLeft + graphWidth - float32(dataPoints-2-i)*graphWidth/float32(gp.maxDataPoints-1)
-			
-			// Calculate Y position (inverted - 0 at bottom)
-			y1 := graphTop + graphHeight - float32(data[i].WriteValue/maxValue)*graphHeight
-			y2 := graphTop + graphHeight - float32(data[i+1].WriteValue/maxValue)*graphHeight
-			
-			// Clamp to graph area
-			if y1 < graphTop {
-				y1 = graphTop
-			}
-			if y2 < graphTop {
-				y2 = graphTop
-			}
-			if y1 > graphTop+graphHeight {
-				y1 = graphTop + graphHeight
-			}
-			if y2 > graphTop+graphHeight {
-				y2 = graphTop + graphHeight
-			}
-			
-			line := canvas.NewLine(writeColor)
-			line.Position1 = fyne.NewPos(x1, y1)
-			line.Position2 = fyne.NewPos(x2, y2)
-			line.StrokeWidth = 2
-			objects = append(objects, line)
-		}
-	}
-	
-	// Define constants for hover line and tooltips
-	graphHeight := float32(100)
-	graphTop := float32(35)
-	graphLeft := float32(40)
-	graphWidth := float32(1050)
+func (gp *GraphPanel) redrawGraph(graphContainer *fyne.Container, data []DataPoint, startIdx, endIdx int, maxValue float64, title string) {
+	data = data[startIdx:endIdx]
+	objects := gp.buildGraphObjects(data, maxValue, title)
+
+	// Constants for hover line and tooltips - must match the Canvas padding
+	// chartRenderer pins the chart image's plot area to (graphPlotLeft/Top/
+	// Width/Height in chart_renderer.go), so the overlay below lines up with
+	// the image underneath it.
+	graphHeight := float32(graphPlotHeight)
+	graphTop := float32(graphPlotTop)
+	graphLeft := float32(graphPlotLeft)
+	graphWidth := float32(graphPlotWidth)
 	
 	// Draw hover line and tooltips if hover is active
 	if gp.hoverActive && gp.hoverX >= graphLeft && gp.hoverX <= graphLeft+graphWidth {
@@ -541,13 +481,13 @@ func (gp *GraphPanel) redrawGraph(graphContainer *fyne.Container, data []DataPoi
 			relativeX := gp.hoverX - graphLeft
 			dataPoints := len(data)
 			
-			// The plotting formula is: x = graphLeft + graphWidth - (dataPoints-1-i)*graphWidth/(maxDataPoints-1)
-			// Solving for i: i = dataPoints - 1 - (graphLeft + graphWidth - hoverX) * (maxDataPoints-1) / graphWidth
-			// Simplifying with relativeX = hoverX - graphLeft:
-			// i = dataPoints - 1 - (graphWidth - relativeX) * (maxDataPoints-1) / graphWidth
-			
-			dataPointsFromRight := (graphWidth - relativeX) * float32(gp.maxDataPoints-1) / graphWidth
-			dataIndex := dataPoints - 1 - int(dataPointsFromRight)
+			// computeSegments places sample i at x = graphWidth + (i-dataPoints)*graphWidth/maxDataPoints
+			// relative to graphLeft (see graph_segments.go). Solving for i with
+			// relativeX = hoverX - graphLeft:
+			// i = dataPoints - (graphWidth - relativeX) * maxDataPoints / graphWidth
+
+			dataPointsFromRight := (graphWidth - relativeX) * float32(gp.maxDataPoints) / graphWidth
+			dataIndex := dataPoints - int(dataPointsFromRight)
 			
 			// Clamp to valid range
 			if dataIndex < 0 {
@@ -590,7 +530,9 @@ func (gp *GraphPanel) redrawGraph(graphContainer *fyne.Container, data []DataPoi
 			}
 		}
 	}
-	
+
+	objects = append(objects, gp.selectionOverlay(title)...)
+
 	graphContainer.Objects = objects
 	graphContainer.Refresh()
 }
@@ -598,7 +540,7 @@ func (gp *GraphPanel) redrawGraph(graphContainer *fyne.Container, data []DataPoi
 func (gp *GraphPanel) Clear() {
 	gp.mu.Lock()
 	defer gp.mu.Unlock()
-	
+
 	gp.throughputData = make([]DataPoint, 0)
 	gp.iopsData = make([]DataPoint, 0)
 	gp.latencyData = make([]DataPoint, 0)
@@ -606,7 +548,11 @@ func (gp *GraphPanel) Clear() {
 	gp.maxThroughput = 2000
 	gp.maxIOPS = 100000
 	gp.maxLatency = 5
-	
+
+	gp.liveTail = true
+	gp.selectionStats = nil
+	gp.replaying = false
+
 	gp.redrawAll()
 }
 
@@ -0,0 +1,154 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgpdf"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// ExportFormat selects which vg backend GraphPanel.Export renders through.
+type ExportFormat int
+
+const (
+	ExportPNG ExportFormat = iota
+	ExportSVG
+	ExportPDF
+)
+
+// colorRead/colorWrite match the blue/pink read/write convention every graph
+// in this GUI uses, so an exported image looks like what was on screen.
+var (
+	colorRead  = color.RGBA{R: 100, G: 149, B: 237, A: 0xff}
+	colorWrite = color.RGBA{R: 0xff, G: 0x14, B: 0x93, A: 0xff}
+)
+
+// Export renders the throughput, IOPS, and latency panels as a single image
+// stacked vertically, in the given format, and writes it to path. It takes
+// its own snapshot of throughputData/iopsData/latencyData under gp.mu, so a
+// run updating the live graphs concurrently can't tear the exported image.
+func (gp *GraphPanel) Export(path string, format ExportFormat) error {
+	gp.mu.Lock()
+	throughput := append([]DataPoint(nil), gp.throughputData...)
+	iopsPoints := append([]DataPoint(nil), gp.iopsData...)
+	latency := append([]DataPoint(nil), gp.latencyData...)
+	gp.mu.Unlock()
+
+	plots := [][]*plot.Plot{
+		{buildMetricPlot("Throughput (MB/s)", throughput)},
+		{buildMetricPlot("IOPS", iopsPoints)},
+		{buildMetricPlot("Latency (ms)", latency)},
+	}
+
+	const width, height = 8 * vg.Inch, 9 * vg.Inch
+
+	var canvas vg.CanvasWriterTo
+	switch format {
+	case ExportSVG:
+		canvas = vgsvg.New(width, height)
+	case ExportPDF:
+		canvas = vgpdf.New(width, height)
+	default:
+		canvas = vgimg.New(width, height)
+	}
+
+	tiles := draw.Tiles{
+		Rows: len(plots), Cols: 1,
+		PadTop: vg.Millimeter * 4, PadBottom: vg.Millimeter * 4,
+		PadLeft: vg.Millimeter * 4, PadRight: vg.Millimeter * 4,
+		PadY: vg.Millimeter * 6,
+	}
+	canvases := plot.Align(plots, tiles, draw.New(canvas))
+	for row := range plots {
+		plots[row][0].Draw(canvases[row][0])
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("export graphs: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := canvas.WriteTo(f); err != nil {
+		return fmt.Errorf("export graphs: %w", err)
+	}
+	return nil
+}
+
+// buildMetricPlot draws one metric's Read/Write series as a pair of
+// plotter.Line series sharing a time X-axis, matching the on-screen
+// blue/pink read/write convention.
+func buildMetricPlot(title string, data []DataPoint) *plot.Plot {
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = "Time (s)"
+	p.Y.Label.Text = title
+	p.Legend.Top = true
+
+	readPts := make(plotter.XYs, len(data))
+	writePts := make(plotter.XYs, len(data))
+	for i, d := range data {
+		readPts[i] = plotter.XY{X: d.Time, Y: d.ReadValue}
+		writePts[i] = plotter.XY{X: d.Time, Y: d.WriteValue}
+	}
+
+	readLine, err := plotter.NewLine(readPts)
+	if err == nil {
+		readLine.Color = colorRead
+		readLine.Width = vg.Points(1.5)
+		p.Add(readLine)
+		p.Legend.Add("Read", readLine)
+	}
+
+	writeLine, err := plotter.NewLine(writePts)
+	if err == nil {
+		writeLine.Color = colorWrite
+		writeLine.Width = vg.Points(1.5)
+		p.Add(writeLine)
+		p.Legend.Add("Write", writeLine)
+	}
+
+	return p
+}
+
+// ShowExportDialog opens a Fyne save-file dialog and exports gp's current
+// graphs to whatever path and format (picked from the chosen extension) the
+// user lands on - the "Export..." menu entry's handler.
+func ShowExportDialog(win fyne.Window, gp *GraphPanel) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		path := writer.URI().Path()
+		format := exportFormatForExtension(writer.URI().Extension())
+		writer.Close()
+
+		if err := gp.Export(path, format); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".svg", ".pdf"}))
+	d.Show()
+}
+
+func exportFormatForExtension(ext string) ExportFormat {
+	switch strings.ToLower(ext) {
+	case ".svg":
+		return ExportSVG
+	case ".pdf":
+		return ExportPDF
+	default:
+		return ExportPNG
+	}
+}
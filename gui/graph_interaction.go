@@ -0,0 +1,288 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// Implement desktop.Mouseable: click-drag pans the visible window, and
+// shift-click-drag selects a range to summarize in selectionOverlay.
+func (o *interactiveOverlay) MouseDown(ev *desktop.MouseEvent) {
+	o.panel.mu.Lock()
+	defer o.panel.mu.Unlock()
+
+	if ev.Modifier&fyne.KeyModifierShift != 0 {
+		o.panel.selecting = true
+		o.panel.selectStartX = ev.Position.X
+		o.panel.selectionStats = nil
+		return
+	}
+
+	total := len(o.panel.throughputData)
+	start, end := o.panel.visibleRange(total)
+	o.panel.viewStart, o.panel.viewEnd = start, end
+	o.panel.liveTail = false
+
+	o.panel.dragging = true
+	o.panel.dragStartX = ev.Position.X
+	o.panel.dragStartView = [2]int{start, end}
+}
+
+func (o *interactiveOverlay) MouseUp(ev *desktop.MouseEvent) {
+	o.panel.mu.Lock()
+	wasSelecting := o.panel.selecting
+	selectStartX := o.panel.selectStartX
+	wasDragging := o.panel.dragging
+	o.panel.selecting = false
+	o.panel.dragging = false
+	o.panel.mu.Unlock()
+
+	if wasSelecting {
+		o.panel.finishSelection(selectStartX, ev.Position.X)
+	}
+	if wasSelecting || wasDragging {
+		o.panel.redrawAll()
+	}
+}
+
+// Implement fyne.Scrollable: the wheel zooms the visible window in or out,
+// keeping it centered on its current midpoint. Zooming out far enough to
+// cover the whole run snaps back to the live rolling tail.
+func (o *interactiveOverlay) Scrolled(ev *fyne.ScrollEvent) {
+	o.panel.mu.Lock()
+	total := len(o.panel.throughputData)
+	start, end := o.panel.visibleRange(total)
+	o.panel.viewStart, o.panel.viewEnd = start, end
+	o.panel.zoom(ev.Scrolled.DY, total)
+	o.panel.mu.Unlock()
+
+	o.panel.redrawAll()
+}
+
+// Implement fyne.DoubleTappable: double-click resets to the live tail view.
+func (o *interactiveOverlay) DoubleTapped(*fyne.PointEvent) {
+	o.panel.mu.Lock()
+	o.panel.liveTail = true
+	o.panel.selectionStats = nil
+	o.panel.mu.Unlock()
+
+	o.panel.redrawAll()
+}
+
+// panBy shifts the view window to follow an in-progress drag, in index
+// units derived from the window's width at drag start. Positive
+// deltaPixels (dragging right) reveals older samples, matching the usual
+// "drag the content, not the window" feel. Caller must hold gp.mu.
+func (gp *GraphPanel) panBy(deltaPixels float32) {
+	total := len(gp.throughputData)
+	width := gp.dragStartView[1] - gp.dragStartView[0]
+	if total == 0 || width <= 0 {
+		return
+	}
+
+	indexDelta := int(-deltaPixels / graphPlotWidth * float32(width))
+	start := gp.dragStartView[0] + indexDelta
+	end := gp.dragStartView[1] + indexDelta
+	if start < 0 {
+		end -= start
+		start = 0
+	}
+	if end > total {
+		start -= end - total
+		end = total
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	gp.viewStart, gp.viewEnd = start, end
+}
+
+// zoom narrows or widens the visible window around its current center by a
+// fixed factor per scroll tick. Caller must hold gp.mu.
+func (gp *GraphPanel) zoom(scrollDY float32, total int) {
+	if total == 0 || scrollDY == 0 {
+		return
+	}
+
+	width := gp.viewEnd - gp.viewStart
+	if width <= 0 {
+		width = gp.maxDataPoints
+	}
+	center := gp.viewStart + width/2
+
+	const zoomFactor = 1.15
+	if scrollDY > 0 {
+		width = int(float64(width) / zoomFactor)
+	} else {
+		width = int(float64(width) * zoomFactor)
+	}
+	if width < 5 {
+		width = 5
+	}
+	if width > total {
+		width = total
+	}
+
+	start := center - width/2
+	end := start + width
+	if start < 0 {
+		end -= start
+		start = 0
+	}
+	if end > total {
+		start -= end - total
+		end = total
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	gp.viewStart, gp.viewEnd = start, end
+	gp.liveTail = width >= total
+}
+
+// rangeStats summarizes one channel (Read or Write) of one metric across a
+// selected index range.
+type rangeStats struct {
+	Min, Max, Avg, P95 float64
+}
+
+func computeRangeStats(data []DataPoint, valueAt func(DataPoint) float64) rangeStats {
+	if len(data) == 0 {
+		return rangeStats{}
+	}
+
+	values := make([]float64, len(data))
+	sum := 0.0
+	for i, dp := range data {
+		v := valueAt(dp)
+		values[i] = v
+		sum += v
+	}
+	sort.Float64s(values)
+
+	p95Index := int(float64(len(values)-1) * 0.95)
+	return rangeStats{
+		Min: values[0],
+		Max: values[len(values)-1],
+		Avg: sum / float64(len(values)),
+		P95: values[p95Index],
+	}
+}
+
+// selectionStats holds the min/max/avg/p95 aggregates a shift-click-drag
+// selection shows for each metric's Read and Write channel.
+type selectionStats struct {
+	StartTime, EndTime float64
+
+	ThroughputRead, ThroughputWrite rangeStats
+	IOPSRead, IOPSWrite             rangeStats
+	LatencyRead, LatencyWrite       rangeStats
+}
+
+// finishSelection turns a shift-click-drag's pixel start/end into an index
+// range over the currently visible window and summarizes it into
+// gp.selectionStats.
+func (gp *GraphPanel) finishSelection(xStart, xEnd float32) {
+	gp.mu.Lock()
+	defer gp.mu.Unlock()
+
+	total := len(gp.throughputData)
+	if total == 0 {
+		return
+	}
+	windowStart, windowEnd := gp.visibleRange(total)
+	windowLen := windowEnd - windowStart
+	if windowLen <= 0 {
+		return
+	}
+
+	toIndex := func(x float32) int {
+		relative := x - graphPlotLeft
+		if relative < 0 {
+			relative = 0
+		}
+		if relative > graphPlotWidth {
+			relative = graphPlotWidth
+		}
+		return windowStart + int(relative/graphPlotWidth*float32(windowLen))
+	}
+
+	i0, i1 := toIndex(xStart), toIndex(xEnd)
+	if i0 > i1 {
+		i0, i1 = i1, i0
+	}
+	if i1 <= i0 {
+		i1 = i0 + 1
+	}
+	if i1 > total {
+		i1 = total
+	}
+	if i0 >= i1 {
+		return
+	}
+
+	readValue := func(dp DataPoint) float64 { return dp.ReadValue }
+	writeValue := func(dp DataPoint) float64 { return dp.WriteValue }
+
+	gp.selectionStats = &selectionStats{
+		StartTime:       gp.throughputData[i0].Time,
+		EndTime:         gp.throughputData[i1-1].Time,
+		ThroughputRead:  computeRangeStats(gp.throughputData[i0:i1], readValue),
+		ThroughputWrite: computeRangeStats(gp.throughputData[i0:i1], writeValue),
+		IOPSRead:        computeRangeStats(gp.iopsData[i0:i1], readValue),
+		IOPSWrite:       computeRangeStats(gp.iopsData[i0:i1], writeValue),
+		LatencyRead:     computeRangeStats(gp.latencyData[i0:i1], readValue),
+		LatencyWrite:    computeRangeStats(gp.latencyData[i0:i1], writeValue),
+	}
+}
+
+// selectionOverlay draws the current selectionStats' Read/Write aggregates
+// for the given panel's metric (matched by its title), or nothing if there
+// is no active selection.
+func (gp *GraphPanel) selectionOverlay(title string) []fyne.CanvasObject {
+	stats := gp.selectionStats
+	if stats == nil {
+		return nil
+	}
+
+	var read, write rangeStats
+	switch title {
+	case "Throughput (MB/s)":
+		read, write = stats.ThroughputRead, stats.ThroughputWrite
+	case "IOPS":
+		read, write = stats.IOPSRead, stats.IOPSWrite
+	case "Latency (ms)":
+		read, write = stats.LatencyRead, stats.LatencyWrite
+	default:
+		return nil
+	}
+
+	const boxWidth, boxLeft = 290, graphPlotLeft + graphPlotWidth - 300
+
+	bg := canvas.NewRectangle(color.RGBA{R: 0, G: 0, B: 0, A: 230})
+	bg.Resize(fyne.NewSize(boxWidth, 55))
+	bg.Move(fyne.NewPos(boxLeft, graphPlotTop+5))
+
+	readLine := canvas.NewText(
+		fmt.Sprintf("Read  min %.1f max %.1f avg %.1f p95 %.1f", read.Min, read.Max, read.Avg, read.P95),
+		color.RGBA{R: 100, G: 149, B: 237, A: 255},
+	)
+	readLine.TextSize = 10
+	readLine.Move(fyne.NewPos(boxLeft+5, graphPlotTop+12))
+
+	writeLine := canvas.NewText(
+		fmt.Sprintf("Write min %.1f max %.1f avg %.1f p95 %.1f", write.Min, write.Max, write.Avg, write.P95),
+		color.RGBA{R: 255, G: 20, B: 147, A: 255},
+	)
+	writeLine.TextSize = 10
+	writeLine.Move(fyne.NewPos(boxLeft+5, graphPlotTop+30))
+
+	return []fyne.CanvasObject{bg, readLine, writeLine}
+}
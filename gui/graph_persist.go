@@ -0,0 +1,129 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// savedRun is the on-disk shape SaveRun/LoadRun exchange, as JSON like the
+// rest of this GUI's persisted state (Sample, config presets, and so on) -
+// readable and diffable when a run is attached to a bug report.
+type savedRun struct {
+	ThroughputData []DataPoint `json:"throughput_data"`
+	IOPSData       []DataPoint `json:"iops_data"`
+	LatencyData    []DataPoint `json:"latency_data"`
+
+	MaxThroughput float64 `json:"max_throughput"`
+	MaxIOPS       float64 `json:"max_iops"`
+	MaxLatency    float64 `json:"max_latency"`
+	MaxDataPoints int     `json:"max_data_points"`
+}
+
+// SaveRun writes gp's current data and scale state to path as JSON, so the
+// run can be reopened later via LoadRun, attached to a bug report, or used
+// as regression-test fixture data for the drawing code.
+func (gp *GraphPanel) SaveRun(path string) error {
+	gp.mu.Lock()
+	run := savedRun{
+		ThroughputData: append([]DataPoint(nil), gp.throughputData...),
+		IOPSData:       append([]DataPoint(nil), gp.iopsData...),
+		LatencyData:    append([]DataPoint(nil), gp.latencyData...),
+		MaxThroughput:  gp.maxThroughput,
+		MaxIOPS:        gp.maxIOPS,
+		MaxLatency:     gp.maxLatency,
+		MaxDataPoints:  gp.maxDataPoints,
+	}
+	gp.mu.Unlock()
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save run: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("save run: %w", err)
+	}
+	return nil
+}
+
+// LoadRun replaces gp's live buffers with the run stored at path and puts gp
+// into replay mode: UpdateData becomes a no-op until EnterLiveMode or Clear
+// is called, so a live benchmark can't overwrite the loaded trace out from
+// under whoever is inspecting it.
+func (gp *GraphPanel) LoadRun(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("load run: %w", err)
+	}
+
+	var run savedRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return fmt.Errorf("load run: %w", err)
+	}
+
+	gp.mu.Lock()
+	gp.throughputData = run.ThroughputData
+	gp.iopsData = run.IOPSData
+	gp.latencyData = run.LatencyData
+	gp.maxThroughput = run.MaxThroughput
+	gp.maxIOPS = run.MaxIOPS
+	gp.maxLatency = run.MaxLatency
+	if run.MaxDataPoints > 0 {
+		gp.maxDataPoints = run.MaxDataPoints
+	}
+	gp.replaying = true
+	gp.liveTail = true
+	gp.selectionStats = nil
+	gp.mu.Unlock()
+
+	gp.redrawAll()
+	return nil
+}
+
+// EnterLiveMode ends replay of a loaded run and lets UpdateData resume
+// appending live samples again.
+func (gp *GraphPanel) EnterLiveMode() {
+	gp.mu.Lock()
+	gp.replaying = false
+	gp.mu.Unlock()
+}
+
+// ShowSaveRunDialog opens a Fyne save-file dialog and writes gp's current
+// run to wherever the user lands - the "Save Run..." menu entry's handler.
+func ShowSaveRunDialog(win fyne.Window, gp *GraphPanel) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		path := writer.URI().Path()
+		writer.Close()
+
+		if err := gp.SaveRun(path); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
+
+// ShowOpenRunDialog opens a Fyne open-file dialog and loads the chosen run
+// into gp for replay - the "Open Run..." menu entry's handler.
+func ShowOpenRunDialog(win fyne.Window, gp *GraphPanel) {
+	d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		reader.Close()
+
+		if err := gp.LoadRun(path); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
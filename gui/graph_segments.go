@@ -0,0 +1,65 @@
+package gui
+
+// segment is one drawable span in computeSegments' output: X0/X1 are pixel
+// offsets from the graph's left edge (not yet an absolute canvas position),
+// Y0/Y1 are the raw data values at those two endpoints (not yet scaled to
+// the graph's height) - callers add graphLeft/graphTop and pick which
+// value-to-pixel scale applies.
+type segment struct {
+	X0, X1 float32
+	Y0, Y1 float64
+}
+
+// computeSegments turns data into the line segments redrawGraph draws,
+// always spanning the full [0, width] X range no matter how many samples
+// have accumulated so far. data is right-aligned to "now" at X=width:
+//
+//   - If fewer than maxPoints samples exist yet, the leading edge is
+//     linearly interpolated from zero (UpdateData trims data to maxPoints,
+//     so no earlier sample ever survives to interpolate from instead) to
+//     the first sample, so the line still reaches the left edge rather
+//     than leaving it blank.
+//   - The newest sample never lands exactly on the right edge - see below -
+//     so its value is held flat out to X=width instead of leaving a gap.
+//
+// Points are spaced width/maxPoints apart (maxPoints slots across the full
+// width), not width/(maxPoints-1): the former is what leaves the rightmost
+// slot for the newest-sample extension above; the latter (the original
+// inline drawing code's denominator) packed maxPoints-1 gaps into the full
+// width, which look identical while full but is off by one slot once
+// extended past a full window - e.g. a second window cycling through would
+// drift out of alignment with the first.
+//
+// valueAt selects which field of DataPoint (ReadValue or WriteValue)
+// contributes each segment's Y.
+func computeSegments(data []DataPoint, maxPoints int, width float32, valueAt func(DataPoint) float64) []segment {
+	if len(data) == 0 || maxPoints <= 0 || width <= 0 {
+		return nil
+	}
+
+	n := len(data)
+	slot := width / float32(maxPoints)
+
+	// xFor right-aligns sample i so the newest sample (i == n-1) sits one
+	// slot before the right edge, leaving room for the flat extension to
+	// X=width every window draws.
+	xFor := func(i int) float32 {
+		return width - float32(n-i)*slot
+	}
+
+	segments := make([]segment, 0, n+1)
+
+	if firstX := xFor(0); firstX > 0 {
+		segments = append(segments, segment{X0: 0, X1: firstX, Y0: 0, Y1: valueAt(data[0])})
+	}
+
+	for i := 0; i < n-1; i++ {
+		segments = append(segments, segment{X0: xFor(i), X1: xFor(i + 1), Y0: valueAt(data[i]), Y1: valueAt(data[i+1])})
+	}
+
+	if lastX := xFor(n - 1); lastX < width {
+		segments = append(segments, segment{X0: lastX, X1: width, Y0: valueAt(data[n-1]), Y1: valueAt(data[n-1])})
+	}
+
+	return segments
+}
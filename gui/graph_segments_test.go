@@ -0,0 +1,93 @@
+package gui
+
+import "testing"
+
+func readValue(dp DataPoint) float64 { return dp.ReadValue }
+
+func TestComputeSegmentsEmpty(t *testing.T) {
+	if segs := computeSegments(nil, 10, 100, readValue); segs != nil {
+		t.Fatalf("expected nil segments for empty data, got %v", segs)
+	}
+}
+
+func TestComputeSegmentsPartialWindowInterpolatesLeadingEdge(t *testing.T) {
+	data := []DataPoint{{ReadValue: 10}}
+	maxPoints := 4
+	width := float32(100)
+
+	segs := computeSegments(data, maxPoints, width, readValue)
+	if len(segs) != 2 {
+		t.Fatalf("expected 2 segments (leading interpolation + trailing extension), got %d: %+v", len(segs), segs)
+	}
+
+	leading := segs[0]
+	if leading.X0 != 0 {
+		t.Errorf("leading segment should start at the graph's left edge, got X0=%v", leading.X0)
+	}
+	if leading.Y0 != 0 {
+		t.Errorf("leading segment should interpolate from zero, got Y0=%v", leading.Y0)
+	}
+	if leading.Y1 != 10 {
+		t.Errorf("leading segment should end at the sample's value, got Y1=%v", leading.Y1)
+	}
+
+	trailing := segs[1]
+	if trailing.X1 != width {
+		t.Errorf("trailing segment should reach the graph's right edge, got X1=%v", trailing.X1)
+	}
+	if trailing.Y0 != 10 || trailing.Y1 != 10 {
+		t.Errorf("trailing segment should hold the newest sample's value flat, got Y0=%v Y1=%v", trailing.Y0, trailing.Y1)
+	}
+	if trailing.X0 != leading.X1 {
+		t.Errorf("segments should be contiguous: leading.X1=%v trailing.X0=%v", leading.X1, trailing.X0)
+	}
+}
+
+func TestComputeSegmentsFullWindowSpansWholeWidth(t *testing.T) {
+	maxPoints := 5
+	width := float32(100)
+	data := make([]DataPoint, maxPoints)
+	for i := range data {
+		data[i] = DataPoint{ReadValue: float64(i)}
+	}
+
+	segs := computeSegments(data, maxPoints, width, readValue)
+	if len(segs) == 0 {
+		t.Fatal("expected at least one segment for a full window")
+	}
+
+	first, last := segs[0], segs[len(segs)-1]
+	if first.X0 != 0 {
+		t.Errorf("first segment should start at the graph's left edge, got X0=%v", first.X0)
+	}
+	if last.X1 != width {
+		t.Errorf("last segment should reach the graph's right edge, got X1=%v", last.X1)
+	}
+
+	// A full window draws maxPoints-1 between-sample segments plus the
+	// trailing extension to the right edge (the newest sample never lands
+	// exactly on the right edge - see computeSegments' doc comment).
+	wantSegments := (maxPoints - 1) + 1
+	if len(segs) != wantSegments {
+		t.Errorf("expected %d segments for a full window, got %d: %+v", wantSegments, len(segs), segs)
+	}
+}
+
+func TestComputeSegmentsContiguousAcrossFullRange(t *testing.T) {
+	maxPoints := 6
+	width := float32(120)
+	data := []DataPoint{{ReadValue: 1}, {ReadValue: 2}, {ReadValue: 3}}
+
+	segs := computeSegments(data, maxPoints, width, readValue)
+	for i := 1; i < len(segs); i++ {
+		if segs[i-1].X1 != segs[i].X0 {
+			t.Errorf("segment %d doesn't connect to segment %d: %+v -> %+v", i-1, i, segs[i-1], segs[i])
+		}
+	}
+	if segs[0].X0 != 0 {
+		t.Errorf("expected first segment to start at 0, got %v", segs[0].X0)
+	}
+	if segs[len(segs)-1].X1 != width {
+		t.Errorf("expected last segment to end at width %v, got %v", width, segs[len(segs)-1].X1)
+	}
+}
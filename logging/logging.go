@@ -0,0 +1,137 @@
+// Package logging provides a small structured logger shared by the
+// benchmark engine and its frontends (the native webview window, the
+// headless serve command, and the plain CLI), so a GUI log console and a
+// plain-text file can both be fed from the same call sites without the
+// engine knowing anything about consoles, WebSockets, or files.
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log Entry, ordered debug < info < warn < error.
+type Level string
+
+const (
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Entry is one structured log line. Fields carries any extra context a
+// particular call site wants attached (device path, byte offset, ...)
+// without forcing every Sink to parse it out of Msg.
+type Entry struct {
+	Level  Level                  `json:"level"`
+	Phase  string                 `json:"phase,omitempty"`
+	TS     time.Time              `json:"ts"`
+	Msg    string                 `json:"msg"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Sink receives every Entry logged through a Logger. Implementations must
+// be safe for concurrent use, since a multi-device run logs from more than
+// one goroutine.
+type Sink interface {
+	Log(Entry)
+}
+
+// Logger fans a stream of Entry values out to zero or more Sinks. The zero
+// value (via NewLogger with no sinks) is safe to log through - callers
+// never need to nil-check before calling Infof etc.
+type Logger struct {
+	mu    sync.Mutex
+	sinks []Sink
+}
+
+// NewLogger creates a Logger that forwards every Entry to each of sinks.
+func NewLogger(sinks ...Sink) *Logger {
+	return &Logger{sinks: sinks}
+}
+
+// AddSink registers an additional sink. Safe to call after logging has
+// already started.
+func (l *Logger) AddSink(s Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, s)
+}
+
+func (l *Logger) log(level Level, phase, format string, args ...interface{}) {
+	entry := Entry{Level: level, Phase: phase, TS: time.Now(), Msg: fmt.Sprintf(format, args...)}
+
+	l.mu.Lock()
+	sinks := l.sinks
+	l.mu.Unlock()
+
+	for _, s := range sinks {
+		s.Log(entry)
+	}
+}
+
+func (l *Logger) Debugf(phase, format string, args ...interface{}) {
+	l.log(LevelDebug, phase, format, args...)
+}
+func (l *Logger) Infof(phase, format string, args ...interface{}) {
+	l.log(LevelInfo, phase, format, args...)
+}
+func (l *Logger) Warnf(phase, format string, args ...interface{}) {
+	l.log(LevelWarn, phase, format, args...)
+}
+func (l *Logger) Errorf(phase, format string, args ...interface{}) {
+	l.log(LevelError, phase, format, args...)
+}
+
+// StdoutSink writes each Entry as one human-readable line to stdout,
+// preserving the plain-text experience of running 4corners from the CLI.
+type StdoutSink struct{}
+
+func (StdoutSink) Log(e Entry) {
+	if e.Phase != "" {
+		fmt.Printf("[%s] %s: %s\n", e.Level, e.Phase, e.Msg)
+	} else {
+		fmt.Printf("[%s] %s\n", e.Level, e.Msg)
+	}
+}
+
+// FileSink appends each Entry as one NDJSON line to w, so a user can review
+// exactly what happened after a long or destructive run finishes - or
+// crashes. w is typically a file the caller already opened, such as the
+// per-run log file the GUI creates alongside a run's graphs.
+type FileSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileSink wraps w, an already-open writer, as a Sink.
+func NewFileSink(w io.Writer) *FileSink {
+	return &FileSink{w: w}
+}
+
+func (s *FileSink) Log(e Entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w.Write(data)
+}
+
+// CallbackSink adapts a plain func(Entry) into a Sink, so a transport layer
+// (an eval-into-the-page bridge, a WebSocket broadcast) can hook in without
+// defining its own one-method type.
+type CallbackSink func(Entry)
+
+func (f CallbackSink) Log(e Entry) { f(e) }
+
+var _ Sink = StdoutSink{}
+var _ Sink = (*FileSink)(nil)
+var _ Sink = CallbackSink(nil)
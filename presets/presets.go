@@ -0,0 +1,130 @@
+// Package presets persists named, reusable benchmark configurations - e.g.
+// "NVMe 4K random" or "HDD sequential" - so a user doesn't have to re-type
+// the Test Configuration form for a setup they run repeatedly.
+package presets
+
+import (
+	"4corners/config"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Preset is one saved Test Configuration, along with the device profile it
+// was tuned for (a free-text label, typically the device's display name),
+// so the UI can warn when a preset is loaded against a different device.
+type Preset struct {
+	Name          string        `json:"name"`
+	DeviceProfile string        `json:"deviceProfile,omitempty"`
+	Config        config.Config `json:"config"`
+}
+
+// Store persists named presets. It's an interface so the REST/webview
+// layers don't have to know presets live in a JSON file on disk - a test or
+// an alternate backend can swap in something else.
+type Store interface {
+	List() ([]Preset, error)
+	Save(p Preset) error
+	Delete(name string) error
+}
+
+// FileStore is the default Store: every preset lives in one JSON file under
+// the user's config dir, the same directory convention benchmark.RunsDir
+// uses for saved runs.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a FileStore backed by presets.json under the user
+// config dir, creating the containing directory if it doesn't exist yet.
+func NewFileStore() (*FileStore, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(base, "4corners")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{path: filepath.Join(dir, "presets.json")}, nil
+}
+
+func (s *FileStore) load() (map[string]Preset, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Preset{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	presets := map[string]Preset{}
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", s.path, err)
+	}
+	return presets, nil
+}
+
+func (s *FileStore) write(presets map[string]Preset) error {
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// List returns every saved preset, sorted by name.
+func (s *FileStore) List() ([]Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Preset, 0, len(presets))
+	for _, p := range presets {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Save writes p, overwriting any existing preset with the same name.
+func (s *FileStore) Save(p Preset) error {
+	if p.Name == "" {
+		return fmt.Errorf("preset name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return err
+	}
+	presets[p.Name] = p
+	return s.write(presets)
+}
+
+// Delete removes the preset named name. It errors if no such preset exists.
+func (s *FileStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := presets[name]; !ok {
+		return fmt.Errorf("preset %q not found", name)
+	}
+	delete(presets, name)
+	return s.write(presets)
+}
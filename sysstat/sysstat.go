@@ -0,0 +1,291 @@
+// Package sysstat samples host-context metrics (CPU, memory, and
+// per-device kernel I/O counters) while a benchmark test runs, so
+// throughput/IOPS/latency numbers can be read alongside the concurrent CPU
+// load and the kernel's own view of the device queue.
+package sysstat
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// sampleInterval is how often the Sampler takes a reading while a test runs.
+const sampleInterval = time.Second
+
+// Sample is a point-in-time snapshot of host and target-device state.
+type Sample struct {
+	Time time.Time
+
+	CPUPercent       float64 // aggregate across all cores: user% + system% (iowait not included)
+	CPUUserPercent   float64
+	CPUSystemPercent float64
+	CPUIOWaitPercent float64
+	CPUCorePercent   []float64 // per-core user% + system%, indexed by core number
+
+	MemUsedBytes   uint64
+	MemTotalBytes  uint64
+	MemCachedBytes uint64
+
+	// Disk fields are zero if devicePath couldn't be matched to a kernel
+	// device (e.g. a dir:// or s3:// target).
+	DiskAvailable  bool
+	DiskIOPS       float64 // kernel-observed reads+writes completed/sec
+	DiskMBps       float64 // kernel-observed bytes read+written/sec
+	DiskQueueDepth float64 // average IOs in progress over the interval
+}
+
+// cpuTicks holds cumulative jiffie counters read from the OS.
+type cpuTicks struct {
+	user, system, iowait, total uint64
+}
+
+// diskTicks holds the cumulative /proc/diskstats-style counters (or their
+// platform equivalent) for a single device.
+type diskTicks struct {
+	readsCompleted, sectorsRead, msReading      uint64
+	writesCompleted, sectorsWritten, msWriting  uint64
+	iosInProgress, msDoingIO, weightedMsDoingIO uint64
+}
+
+// memInfo holds used/total/cached bytes of physical memory.
+type memInfo struct {
+	usedBytes, totalBytes, cachedBytes uint64
+}
+
+// Sampler takes Sample readings at 1Hz in the background for the duration
+// of a benchmark test.
+type Sampler struct {
+	device string
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+
+	mu      sync.Mutex
+	samples []Sample
+}
+
+// NewSampler returns a Sampler that, once started, reports host metrics
+// alongside kernel-observed I/O counters for device.
+func NewSampler(device string) *Sampler {
+	return &Sampler{device: device}
+}
+
+// Start begins sampling in the background. Call Stop to end sampling and
+// collect the series.
+func (s *Sampler) Start() {
+	s.stopChan = make(chan struct{})
+	s.doneChan = make(chan struct{})
+	go s.run()
+}
+
+// Stop ends sampling and returns every Sample taken since Start.
+func (s *Sampler) Stop() []Sample {
+	close(s.stopChan)
+	<-s.doneChan
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.samples
+}
+
+func (s *Sampler) run() {
+	defer close(s.doneChan)
+
+	prevCPU, _ := readCPUTicks()
+	prevCores, _ := readCPUTicksPerCore()
+	prevDisk, haveDisk := readDiskTicks(s.device)
+
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			sample := Sample{Time: now}
+
+			if cur, err := readCPUTicks(); err == nil {
+				sample.CPUUserPercent, sample.CPUSystemPercent, sample.CPUIOWaitPercent, sample.CPUPercent = cpuPercent(prevCPU, cur)
+				prevCPU = cur
+			}
+
+			if cur, err := readCPUTicksPerCore(); err == nil && len(cur) == len(prevCores) {
+				sample.CPUCorePercent = make([]float64, len(cur))
+				for i := range cur {
+					_, _, _, sample.CPUCorePercent[i] = cpuPercent(prevCores[i], cur[i])
+				}
+				prevCores = cur
+			}
+
+			if mem, err := readMemInfo(); err == nil {
+				sample.MemUsedBytes = mem.usedBytes
+				sample.MemTotalBytes = mem.totalBytes
+				sample.MemCachedBytes = mem.cachedBytes
+			}
+
+			if haveDisk {
+				if cur, ok := readDiskTicks(s.device); ok {
+					sample.DiskAvailable = true
+					sample.DiskIOPS, sample.DiskMBps, sample.DiskQueueDepth = diskRates(prevDisk, cur, sampleInterval)
+					prevDisk = cur
+				}
+			}
+
+			s.mu.Lock()
+			s.samples = append(s.samples, sample)
+			s.mu.Unlock()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// readCPUTicks, readMemInfo, and readDiskTicks dispatch to the
+// platform-specific readers, the same way benchmark.openDeviceForRead
+// dispatches on runtime.GOOS rather than using build-tag-selected names
+// directly.
+func readCPUTicks() (cpuTicks, error) {
+	if runtime.GOOS == "windows" {
+		return readCPUTicksWindows()
+	}
+	return readCPUTicksLinux()
+}
+
+func readMemInfo() (memInfo, error) {
+	if runtime.GOOS == "windows" {
+		return readMemInfoWindows()
+	}
+	return readMemInfoLinux()
+}
+
+func readDiskTicks(device string) (diskTicks, bool) {
+	if runtime.GOOS == "windows" {
+		return readDiskTicksWindows(device)
+	}
+	return readDiskTicksLinux(device)
+}
+
+func readCPUTicksPerCore() ([]cpuTicks, error) {
+	if runtime.GOOS == "windows" {
+		return readCPUTicksPerCoreWindows()
+	}
+	return readCPUTicksPerCoreLinux()
+}
+
+// cpuPercent converts the tick delta between two cumulative readings into
+// user/system/iowait/total percentages of elapsed CPU time.
+func cpuPercent(prev, cur cpuTicks) (userPct, systemPct, iowaitPct, totalPct float64) {
+	deltaTotal := float64(cur.total - prev.total)
+	if deltaTotal <= 0 {
+		return 0, 0, 0, 0
+	}
+	userPct = float64(cur.user-prev.user) / deltaTotal * 100
+	systemPct = float64(cur.system-prev.system) / deltaTotal * 100
+	iowaitPct = float64(cur.iowait-prev.iowait) / deltaTotal * 100
+	totalPct = userPct + systemPct
+	return
+}
+
+// diskSectorBytes is the sector size /proc/diskstats counts sectors in,
+// which is fixed at 512 bytes regardless of the device's logical block size.
+const diskSectorBytes = 512
+
+// diskRates converts the tick delta between two cumulative diskstats
+// readings into kernel-observed IOPS, MB/s, and average queue depth.
+func diskRates(prev, cur diskTicks, interval time.Duration) (iops, mbps, qdepth float64) {
+	seconds := interval.Seconds()
+	if seconds <= 0 {
+		return 0, 0, 0
+	}
+
+	deltaOps := float64((cur.readsCompleted - prev.readsCompleted) + (cur.writesCompleted - prev.writesCompleted))
+	deltaSectors := float64((cur.sectorsRead - prev.sectorsRead) + (cur.sectorsWritten - prev.sectorsWritten))
+	deltaWeightedMs := float64(cur.weightedMsDoingIO - prev.weightedMsDoingIO)
+
+	iops = deltaOps / seconds
+	mbps = (deltaSectors * diskSectorBytes) / seconds / (1024 * 1024)
+	// avg queue depth, the same weighted-ms-doing-io/elapsed-ms formula
+	// iostat uses for avgqu-sz.
+	qdepth = deltaWeightedMs / float64(interval.Milliseconds())
+	return
+}
+
+// Summary aggregates a Sample series taken during one test into the
+// single-line form printed alongside the engine's own throughput/IOPS
+// numbers: CPU load (average, p50/p95/max, iowait), average kernel queue
+// depth, kernel-observed IOPS/MB/s, and the portion of that kernel traffic
+// not attributable to the benchmark itself.
+type Summary struct {
+	AvgCPUPercent    float64
+	P50CPUPercent    float64
+	P95CPUPercent    float64
+	MaxCPUPercent    float64
+	AvgIOWaitPercent float64
+	AvgQueueDepth    float64
+	KernelIOPS       float64
+	KernelMBps       float64
+	DiskAvailable    bool
+
+	// OtherProcessMBps is KernelMBps minus ownMBps, floored at zero: kernel
+	// disk traffic the benchmark itself didn't cause, i.e. a noisy
+	// neighbor sharing the device.
+	OtherProcessMBps float64
+}
+
+// Summarize averages a Sample series into a Summary. ownMBps is the
+// benchmark's own achieved throughput during the same window, used to
+// split kernel-observed disk traffic into this run's share versus other
+// processes'. Disk fields are left zero (DiskAvailable false) if no sample
+// in the series resolved a kernel device for the benchmark target.
+func Summarize(samples []Sample, ownMBps float64) Summary {
+	var sum Summary
+	if len(samples) == 0 {
+		return sum
+	}
+
+	cpuPercents := make([]float64, 0, len(samples))
+	var diskSamples int
+	for _, s := range samples {
+		sum.AvgCPUPercent += s.CPUPercent
+		sum.AvgIOWaitPercent += s.CPUIOWaitPercent
+		cpuPercents = append(cpuPercents, s.CPUPercent)
+		if s.CPUPercent > sum.MaxCPUPercent {
+			sum.MaxCPUPercent = s.CPUPercent
+		}
+		if s.DiskAvailable {
+			sum.AvgQueueDepth += s.DiskQueueDepth
+			sum.KernelIOPS += s.DiskIOPS
+			sum.KernelMBps += s.DiskMBps
+			diskSamples++
+		}
+	}
+
+	n := float64(len(samples))
+	sum.AvgCPUPercent /= n
+	sum.AvgIOWaitPercent /= n
+	sum.P50CPUPercent = percentile(cpuPercents, 0.50)
+	sum.P95CPUPercent = percentile(cpuPercents, 0.95)
+	if diskSamples > 0 {
+		sum.DiskAvailable = true
+		sum.AvgQueueDepth /= float64(diskSamples)
+		sum.KernelIOPS /= float64(diskSamples)
+		sum.KernelMBps /= float64(diskSamples)
+		if sum.KernelMBps > ownMBps {
+			sum.OtherProcessMBps = sum.KernelMBps - ownMBps
+		}
+	}
+	return sum
+}
+
+// percentile returns the p-th percentile (0-1) of values as the sorted
+// value at index int(p*(len-1)), without interpolating between neighbors.
+// It does not mutate values.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -0,0 +1,202 @@
+//go:build linux
+// +build linux
+
+package sysstat
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readCPUTicksLinux parses the aggregate "cpu" line of /proc/stat. Fields are
+// cumulative jiffies since boot: user, nice, system, idle, iowait, irq,
+// softirq, steal, guest, guest_nice.
+func readCPUTicksLinux() (cpuTicks, error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return cpuTicks{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return cpuTicks{}, scanner.Err()
+	}
+
+	fields := strings.Fields(scanner.Text())
+	if len(fields) < 6 || fields[0] != "cpu" {
+		return cpuTicks{}, os.ErrInvalid
+	}
+
+	vals := make([]uint64, len(fields)-1)
+	for i, field := range fields[1:] {
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return cpuTicks{}, err
+		}
+		vals[i] = v
+	}
+
+	var total uint64
+	for _, v := range vals {
+		total += v
+	}
+
+	return cpuTicks{
+		user:   vals[0] + vals[1], // user + nice
+		system: vals[2],
+		iowait: vals[4],
+		total:  total,
+	}, nil
+}
+
+// readCPUTicksPerCoreLinux parses the per-core "cpuN" lines of /proc/stat,
+// which follow the same field layout as the aggregate "cpu" line.
+func readCPUTicksPerCoreLinux() ([]cpuTicks, error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cores []cpuTicks
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "cpu") || fields[0] == "cpu" {
+			continue
+		}
+
+		vals := make([]uint64, len(fields)-1)
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = v
+		}
+
+		var total uint64
+		for _, v := range vals {
+			total += v
+		}
+		cores = append(cores, cpuTicks{
+			user:   vals[0] + vals[1],
+			system: vals[2],
+			iowait: vals[4],
+			total:  total,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cores, nil
+}
+
+// readMemInfoLinux parses MemTotal, MemAvailable, and Cached (kB) out of
+// /proc/meminfo.
+func readMemInfoLinux() (memInfo, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return memInfo{}, err
+	}
+	defer f.Close()
+
+	var totalKB, availKB, cachedKB uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch strings.TrimSuffix(fields[0], ":") {
+		case "MemTotal":
+			totalKB, _ = strconv.ParseUint(fields[1], 10, 64)
+		case "MemAvailable":
+			availKB, _ = strconv.ParseUint(fields[1], 10, 64)
+		case "Cached":
+			cachedKB, _ = strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return memInfo{}, err
+	}
+
+	return memInfo{
+		totalBytes:  totalKB * 1024,
+		usedBytes:   (totalKB - availKB) * 1024,
+		cachedBytes: cachedKB * 1024,
+	}, nil
+}
+
+// diskstatsDeviceName maps a Config.Device URL or raw path to the device
+// name /proc/diskstats lists it under, e.g. "/dev/sda" -> "sda". Targets
+// that aren't a raw block device path (dir://, s3://, http(s)://) have no
+// corresponding diskstats entry.
+func diskstatsDeviceName(device string) string {
+	path := device
+	if idx := strings.Index(device, "://"); idx >= 0 {
+		scheme := device[:idx]
+		if scheme != "file" {
+			return ""
+		}
+		path = device[idx+3:]
+	}
+	return strings.TrimPrefix(filepath.Clean(path), "/dev/")
+}
+
+// readDiskTicksLinux looks up device's line in /proc/diskstats. Fields after the
+// device name are, per Documentation/admin-guide/iostats.rst: reads
+// completed, reads merged, sectors read, ms reading, writes completed,
+// writes merged, sectors written, ms writing, IOs in progress, ms doing
+// IO, weighted ms doing IO.
+func readDiskTicksLinux(device string) (diskTicks, bool) {
+	name := diskstatsDeviceName(device)
+	if name == "" {
+		return diskTicks{}, false
+	}
+
+	f, err := os.Open("/proc/diskstats")
+	if err != nil {
+		return diskTicks{}, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 14 || fields[2] != name {
+			continue
+		}
+
+		get := func(i int) uint64 {
+			v, _ := strconv.ParseUint(fields[i], 10, 64)
+			return v
+		}
+
+		return diskTicks{
+			readsCompleted:    get(3),
+			sectorsRead:       get(5),
+			msReading:         get(6),
+			writesCompleted:   get(7),
+			sectorsWritten:    get(9),
+			msWriting:         get(10),
+			iosInProgress:     get(11),
+			msDoingIO:         get(12),
+			weightedMsDoingIO: get(13),
+		}, true
+	}
+
+	return diskTicks{}, false
+}
+
+// Stubs for cross-compilation; never called on linux (sysstat.go dispatches
+// on runtime.GOOS), but must exist so the platform-agnostic dispatcher
+// builds.
+func readCPUTicksWindows() (cpuTicks, error)               { return cpuTicks{}, nil }
+func readCPUTicksPerCoreWindows() ([]cpuTicks, error)      { return nil, nil }
+func readMemInfoWindows() (memInfo, error)                 { return memInfo{}, nil }
+func readDiskTicksWindows(device string) (diskTicks, bool) { return diskTicks{}, false }
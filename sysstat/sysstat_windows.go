@@ -0,0 +1,246 @@
+//go:build windows
+// +build windows
+
+package sysstat
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// numCPU is the number of logical CPUs to query per-core performance
+// counters for; runtime.NumCPU reflects the same GOMAXPROCS-independent
+// count NtQuerySystemInformation fills one entry per.
+func numCPU() int { return runtime.NumCPU() }
+
+// Stubs for cross-compilation; never called on windows (sysstat.go
+// dispatches on runtime.GOOS), but must exist so the platform-agnostic
+// dispatcher builds.
+func readCPUTicksLinux() (cpuTicks, error)               { return cpuTicks{}, nil }
+func readCPUTicksPerCoreLinux() ([]cpuTicks, error)      { return nil, nil }
+func readMemInfoLinux() (memInfo, error)                 { return memInfo{}, nil }
+func readDiskTicksLinux(device string) (diskTicks, bool) { return diskTicks{}, false }
+
+const ioctlDiskPerformance = 0x70020
+
+// diskPerformance mirrors Windows' DISK_PERFORMANCE struct, the raw
+// cumulative-since-boot counters behind the PhysicalDisk performance
+// counters, fetched directly via DeviceIoControl the same way
+// getDeviceSizeWindows already does for IOCTL_DISK_GET_LENGTH_INFO.
+type diskPerformance struct {
+	BytesRead           int64
+	BytesWritten        int64
+	ReadTime            int64 // 100ns units, cumulative
+	WriteTime           int64 // 100ns units, cumulative
+	IdleTime            int64
+	ReadCount           uint32
+	WriteCount          uint32
+	QueueDepth          uint32
+	SplitCount          uint32
+	QueryTime           int64
+	StorageDeviceNumber uint32
+	StorageManagerName  [8]uint16
+}
+
+var (
+	kernel32DLL              = syscall.NewLazyDLL("kernel32.dll")
+	procGetSystemTimes       = kernel32DLL.NewProc("GetSystemTimes")
+	procGlobalMemoryStatusEx = kernel32DLL.NewProc("GlobalMemoryStatusEx")
+	procDeviceIoControl      = kernel32DLL.NewProc("DeviceIoControl")
+)
+
+// readCPUTicksWindows reads cumulative idle/kernel/user time since boot via
+// GetSystemTimes, the closest Windows equivalent of /proc/stat's "cpu" line.
+// lpKernelTime already includes lpIdleTime, so system (busy kernel) time is
+// kernelTicks-idleTicks; Windows exposes no iowait-equivalent counter.
+func readCPUTicksWindows() (cpuTicks, error) {
+	var idle, kernel, user syscall.Filetime
+	r1, _, err := procGetSystemTimes.Call(
+		uintptr(unsafe.Pointer(&idle)),
+		uintptr(unsafe.Pointer(&kernel)),
+		uintptr(unsafe.Pointer(&user)),
+	)
+	if r1 == 0 {
+		return cpuTicks{}, fmt.Errorf("GetSystemTimes failed: %v", err)
+	}
+
+	idleTicks := filetimeToTicks(idle)
+	kernelTicks := filetimeToTicks(kernel)
+	userTicks := filetimeToTicks(user)
+
+	return cpuTicks{
+		user:   userTicks,
+		system: kernelTicks - idleTicks,
+		iowait: 0,
+		total:  kernelTicks + userTicks,
+	}, nil
+}
+
+func filetimeToTicks(ft syscall.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
+
+var (
+	ntdllDLL                       = syscall.NewLazyDLL("ntdll.dll")
+	procNtQuerySystemInformation   = ntdllDLL.NewProc("NtQuerySystemInformation")
+	systemProcessorPerformanceInfo = 8
+)
+
+// systemProcessorPerformanceInformation mirrors Windows'
+// SYSTEM_PROCESSOR_PERFORMANCE_INFORMATION struct, one instance per
+// logical CPU, as returned by NtQuerySystemInformation. All times are
+// 100ns units cumulative since boot, the per-core equivalent of what
+// readCPUTicksWindows reads in aggregate via GetSystemTimes.
+type systemProcessorPerformanceInformation struct {
+	IdleTime       int64
+	KernelTime     int64
+	UserTime       int64
+	DpcTime        int64
+	InterruptTime  int64
+	InterruptCount uint32
+	_              uint32 // padding to 8-byte align the next instance in the array
+}
+
+// readCPUTicksPerCoreWindows reads per-logical-CPU idle/kernel/user time
+// since boot via NtQuerySystemInformation(SystemProcessorPerformanceInformation),
+// the per-core counterpart of readCPUTicksWindows.
+func readCPUTicksPerCoreWindows() ([]cpuTicks, error) {
+	n := numCPU()
+	buf := make([]systemProcessorPerformanceInformation, n)
+	size := uintptr(n) * unsafe.Sizeof(buf[0])
+
+	var returnedLen uint32
+	status, _, _ := procNtQuerySystemInformation.Call(
+		uintptr(systemProcessorPerformanceInfo),
+		uintptr(unsafe.Pointer(&buf[0])),
+		size,
+		uintptr(unsafe.Pointer(&returnedLen)),
+	)
+	if status != 0 {
+		return nil, fmt.Errorf("NtQuerySystemInformation failed: status 0x%x", status)
+	}
+
+	cores := make([]cpuTicks, n)
+	for i, info := range buf {
+		kernelTicks := uint64(info.KernelTime)
+		userTicks := uint64(info.UserTime)
+		idleTicks := uint64(info.IdleTime)
+		cores[i] = cpuTicks{
+			user:   userTicks,
+			system: kernelTicks - idleTicks,
+			total:  kernelTicks + userTicks,
+		}
+	}
+	return cores, nil
+}
+
+// memoryStatusEx mirrors Windows' MEMORYSTATUSEX struct.
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+func readMemInfoWindows() (memInfo, error) {
+	var status memoryStatusEx
+	status.Length = uint32(unsafe.Sizeof(status))
+
+	r1, _, err := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if r1 == 0 {
+		return memInfo{}, fmt.Errorf("GlobalMemoryStatusEx failed: %v", err)
+	}
+
+	return memInfo{
+		totalBytes: status.TotalPhys,
+		usedBytes:  status.TotalPhys - status.AvailPhys,
+	}, nil
+}
+
+// queueDepthIntegral accumulates QueueDepth * elapsed-time into a
+// weighted-ms-doing-io-style running total, since DISK_PERFORMANCE only
+// exposes QueueDepth as an instantaneous value rather than the cumulative
+// counter /proc/diskstats provides. Guarded by a mutex because it's process
+// (not per-Sampler) state, but in practice only one benchmark test samples
+// a device at a time.
+var (
+	queueDepthIntegralMu   sync.Mutex
+	queueDepthIntegralMs   uint64
+	queueDepthIntegralLast time.Time
+)
+
+func accumulateQueueDepthIntegral(queueDepth uint32, now time.Time) uint64 {
+	queueDepthIntegralMu.Lock()
+	defer queueDepthIntegralMu.Unlock()
+
+	if !queueDepthIntegralLast.IsZero() {
+		elapsedMs := uint64(now.Sub(queueDepthIntegralLast).Milliseconds())
+		queueDepthIntegralMs += uint64(queueDepth) * elapsedMs
+	}
+	queueDepthIntegralLast = now
+	return queueDepthIntegralMs
+}
+
+// readDiskTicksWindows opens device (e.g. \\.\PhysicalDrive0) read-only and
+// issues IOCTL_DISK_PERFORMANCE to read its cumulative counters. Targets
+// that aren't a raw device path (dir://, s3://, http(s)://) fail to open
+// and report ok=false.
+func readDiskTicksWindows(device string) (diskTicks, bool) {
+	pathPtr, err := syscall.UTF16PtrFromString(device)
+	if err != nil {
+		return diskTicks{}, false
+	}
+
+	handle, err := syscall.CreateFile(
+		pathPtr,
+		syscall.GENERIC_READ,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE,
+		nil,
+		syscall.OPEN_EXISTING,
+		0,
+		0,
+	)
+	if err != nil || handle == syscall.InvalidHandle {
+		return diskTicks{}, false
+	}
+	defer syscall.CloseHandle(handle)
+
+	var perf diskPerformance
+	var bytesReturned uint32
+	r1, _, _ := procDeviceIoControl.Call(
+		uintptr(handle),
+		uintptr(ioctlDiskPerformance),
+		0,
+		0,
+		uintptr(unsafe.Pointer(&perf)),
+		uintptr(unsafe.Sizeof(perf)),
+		uintptr(unsafe.Pointer(&bytesReturned)),
+		0,
+	)
+	if r1 == 0 {
+		return diskTicks{}, false
+	}
+
+	msDoingIO := (uint64(perf.ReadTime) + uint64(perf.WriteTime)) / 10000 // 100ns -> ms
+
+	return diskTicks{
+		readsCompleted:    uint64(perf.ReadCount),
+		sectorsRead:       uint64(perf.BytesRead) / diskSectorBytes,
+		msReading:         uint64(perf.ReadTime) / 10000,
+		writesCompleted:   uint64(perf.WriteCount),
+		sectorsWritten:    uint64(perf.BytesWritten) / diskSectorBytes,
+		msWriting:         uint64(perf.WriteTime) / 10000,
+		iosInProgress:     uint64(perf.QueueDepth),
+		msDoingIO:         msDoingIO,
+		weightedMsDoingIO: accumulateQueueDepthIntegral(perf.QueueDepth, time.Now()),
+	}, true
+}